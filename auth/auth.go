@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrMissingToken リクエストにトークンが指定されていない
+	ErrMissingToken = errors.New("auth: missing token")
+	// ErrInvalidToken トークンの形式、署名、有効期限などが不正
+	ErrInvalidToken = errors.New("auth: invalid token")
+)
+
+// Principal トークンで認証したユーザー
+type Principal struct {
+	UserID uint32
+	// Authority ユーザーの権限(model.User.Authority)
+	Authority uint32
+}
+
+type principalKey struct{}
+
+// NewContext 認証したユーザーを設定したコンテキストを返す
+func NewContext(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext コンテキストに設定された、認証したユーザーを返す
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
@@ -0,0 +1,383 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yzmw1213/PostService/config"
+)
+
+const (
+	// AlgHS256 共通鍵(HMAC SHA-256)による署名
+	AlgHS256 string = "HS256"
+	// AlgRS256 公開鍵(RSA PKCS#1 v1.5 SHA-256)による署名
+	AlgRS256 string = "RS256"
+	// clockSkew 有効期限の検証で許容する、発行元とのずれ
+	clockSkew = 30 * time.Second
+	// maxTokenLength 受け付けるトークンの最大長。デコード前に大きすぎるトークンを拒否する
+	maxTokenLength = 8 * 1024
+	// minRSAKeyBits、maxRSAKeyBits 受け付けるRSAの公開鍵の長さ。大きすぎる鍵は署名の検証に時間がかかるため読み込まない
+	minRSAKeyBits = 2048
+	maxRSAKeyBits = 8192
+)
+
+// verificationKey 署名を検証する鍵
+type verificationKey struct {
+	// id JWKSで指定された鍵ID(kid)。指定がない場合は空文字
+	id     string
+	alg    string
+	secret []byte
+	public *rsa.PublicKey
+}
+
+// Verifier JWTの署名とクレームを検証する
+type Verifier struct {
+	keys     []verificationKey
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// header JWTのヘッダー
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	// Crit 検証側が理解する必要のある拡張ヘッダー。対応する拡張はないため、指定された場合は受け付けない
+	Crit []string `json:"crit,omitempty"`
+}
+
+// claims JWTのクレーム
+// user_id、authorityはUserServiceのAuthと同じ名前とする
+type claims struct {
+	Subject   string   `json:"sub,omitempty"`
+	UserID    uint32   `json:"user_id"`
+	Authority uint32   `json:"authority"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+}
+
+// audience 文字列か文字列の配列で指定されるaudクレーム
+type audience []string
+
+// UnmarshalJSON 文字列、文字列の配列のどちらの形式も読み込む
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// NewVerifier 設定で指定された鍵でトークンを検証するVerifierを生成する
+func NewVerifier(cfg config.Auth) (*Verifier, error) {
+	v := &Verifier{issuer: cfg.Issuer, audience: cfg.Audience, now: time.Now}
+	if cfg.HMACSecret != "" {
+		v.keys = append(v.keys, verificationKey{alg: AlgHS256, secret: []byte(cfg.HMACSecret)})
+	}
+	if cfg.RSAPublicKeyFile != "" {
+		data, err := ioutil.ReadFile(cfg.RSAPublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵を読み込めません: %v", err)
+		}
+		public, err := parseRSAPublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵%sの形式が不正です: %v", cfg.RSAPublicKeyFile, err)
+		}
+		if err := checkRSAKeySize(public); err != nil {
+			return nil, fmt.Errorf("公開鍵%sは使えません: %v", cfg.RSAPublicKeyFile, err)
+		}
+		v.keys = append(v.keys, verificationKey{alg: AlgRS256, public: public})
+	}
+	if cfg.JWKSFile != "" {
+		data, err := ioutil.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("JWKSを読み込めません: %v", err)
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS%sの形式が不正です: %v", cfg.JWKSFile, err)
+		}
+		v.keys = append(v.keys, keys...)
+	}
+	if len(v.keys) == 0 {
+		return nil, errors.New("トークンを検証する鍵が指定されていません")
+	}
+	return v, nil
+}
+
+// Verify トークンの署名、有効期限、発行者、対象を検証し、認証したユーザーを返す
+func (v *Verifier) Verify(token string) (Principal, error) {
+	if len(token) > maxTokenLength {
+		return Principal{}, invalid("token too large")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Principal{}, invalid("malformed token")
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return Principal{}, invalid("malformed header: %v", err)
+	}
+	if len(h.Crit) > 0 {
+		return Principal{}, invalid("unsupported crit %v", h.Crit)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Principal{}, invalid("malformed signature: %v", err)
+	}
+	if err := v.verifySignature(h, parts[0]+"."+parts[1], signature); err != nil {
+		return Principal{}, err
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return Principal{}, invalid("malformed claims: %v", err)
+	}
+	if err := v.verifyClaims(c); err != nil {
+		return Principal{}, err
+	}
+	userID := c.UserID
+	if userID == 0 {
+		// user_idがない場合はsubをユーザーIDとする
+		sub, err := strconv.ParseUint(c.Subject, 10, 32)
+		if err != nil || sub == 0 {
+			return Principal{}, invalid("missing user_id")
+		}
+		userID = uint32(sub)
+	}
+	return Principal{UserID: userID, Authority: c.Authority}, nil
+}
+
+// verifySignature ヘッダーのalgに対応する鍵で署名を検証する
+// kidが指定された場合は、鍵IDが一致する鍵のみを使う
+func (v *Verifier) verifySignature(h header, signingInput string, signature []byte) error {
+	if h.Alg != AlgHS256 && h.Alg != AlgRS256 {
+		return invalid("unsupported alg %q", h.Alg)
+	}
+	for _, key := range v.keys {
+		// 公開鍵を共通鍵として使われないように、鍵の種類とalgが一致する場合のみ検証する
+		if key.alg != h.Alg || (h.Kid != "" && key.id != "" && key.id != h.Kid) {
+			continue
+		}
+		if key.verify(signingInput, signature) {
+			return nil
+		}
+	}
+	return invalid("signature mismatch")
+}
+
+// verify 鍵で署名を検証する
+func (k verificationKey) verify(signingInput string, signature []byte) bool {
+	switch k.alg {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write([]byte(signingInput))
+		return hmac.Equal(mac.Sum(nil), signature)
+	case AlgRS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		return rsa.VerifyPKCS1v15(k.public, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
+
+// verifyClaims 有効期限、発行者、対象を検証する。有効期限のないトークンは受け付けない
+func (v *Verifier) verifyClaims(c claims) error {
+	now := v.now()
+	if c.ExpiresAt == 0 {
+		return invalid("missing exp")
+	}
+	if now.After(time.Unix(c.ExpiresAt, 0).Add(clockSkew)) {
+		return invalid("token expired")
+	}
+	if c.NotBefore != 0 && now.Add(clockSkew).Before(time.Unix(c.NotBefore, 0)) {
+		return invalid("token not yet valid")
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return invalid("unexpected iss %q", c.Issuer)
+	}
+	if v.audience != "" && !c.Audience.contains(v.audience) {
+		return invalid("unexpected aud %v", []string(c.Audience))
+	}
+	return nil
+}
+
+// contains 対象にnameが含まれるか判定する
+func (a audience) contains(name string) bool {
+	for _, aud := range a {
+		if aud == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SignHS256 共通鍵で署名したトークンを発行する。テスト、開発環境での動作確認に使う
+func SignHS256(secret []byte, principal Principal, expiresAt time.Time) (string, error) {
+	h, err := encodeSegment(header{Alg: AlgHS256})
+	if err != nil {
+		return "", err
+	}
+	c, err := encodeSegment(claims{
+		Subject:   strconv.FormatUint(uint64(principal.UserID), 10),
+		UserID:    principal.UserID,
+		Authority: principal.Authority,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := h + "." + c
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// jwks 鍵の一覧(RFC 7517)
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwk 鍵1つ。RSAの公開鍵(kty=RSA)と共通鍵(kty=oct)に対応する
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// parseJWKS JWKSから署名の検証に使う鍵を読み込む
+func parseJWKS(data []byte) ([]verificationKey, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	var keys []verificationKey
+	for _, k := range set.Keys {
+		// 暗号化用の鍵は使わない
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		switch k.Kty {
+		case "RSA":
+			if k.Alg != "" && k.Alg != AlgRS256 {
+				continue
+			}
+			n, err := base64.RawURLEncoding.DecodeString(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("kid %q: n: %v", k.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(k.E)
+			if err != nil {
+				return nil, fmt.Errorf("kid %q: e: %v", k.Kid, err)
+			}
+			exponent := new(big.Int).SetBytes(e)
+			if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+				return nil, fmt.Errorf("kid %q: e is too large", k.Kid)
+			}
+			public := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+			if err := checkRSAKeySize(public); err != nil {
+				return nil, fmt.Errorf("kid %q: %v", k.Kid, err)
+			}
+			keys = append(keys, verificationKey{id: k.Kid, alg: AlgRS256, public: public})
+		case "oct":
+			if k.Alg != "" && k.Alg != AlgHS256 {
+				continue
+			}
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil {
+				return nil, fmt.Errorf("kid %q: k: %v", k.Kid, err)
+			}
+			keys = append(keys, verificationKey{id: k.Kid, alg: AlgHS256, secret: secret})
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no usable keys")
+	}
+	return keys, nil
+}
+
+// checkRSAKeySize 公開鍵の長さが受け付ける範囲か検証する
+func checkRSAKeySize(public *rsa.PublicKey) error {
+	bits := public.N.BitLen()
+	if bits < minRSAKeyBits || bits > maxRSAKeyBits {
+		return fmt.Errorf("key size %d bits is out of range %d-%d", bits, minRSAKeyBits, maxRSAKeyBits)
+	}
+	return nil
+}
+
+// parseRSAPublicKey PEM形式の公開鍵(PKIX、PKCS#1)、証明書からRSAの公開鍵を読み込む
+func parseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if public, ok := cert.PublicKey.(*rsa.PublicKey); ok {
+			return public, nil
+		}
+		return nil, errors.New("not an RSA certificate")
+	}
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaPublic, ok := public.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	return rsaPublic, nil
+}
+
+// decodeSegment base64urlで符号化されたJSONを読み込む
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// encodeSegment JSONをbase64urlで符号化する
+func encodeSegment(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// invalid ErrInvalidTokenに理由を付けたエラーを返す
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidToken, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,366 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/config"
+)
+
+var testSecret = []byte("test-secret")
+
+// signToken ヘッダーとクレームを指定してトークンを発行する
+func signToken(t *testing.T, h map[string]interface{}, c map[string]interface{}, sign func(signingInput string) []byte) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(h) + "." + encode(c)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign(signingInput))
+}
+
+// signHMAC 共通鍵で署名する
+func signHMAC(secret []byte) func(string) []byte {
+	return func(signingInput string) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(signingInput))
+		return mac.Sum(nil)
+	}
+}
+
+// signRSA 秘密鍵で署名する
+func signRSA(t *testing.T, private *rsa.PrivateKey) func(string) []byte {
+	return func(signingInput string) []byte {
+		digest := sha256.Sum256([]byte(signingInput))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	}
+}
+
+// writeFile テスト用の一時ファイルに書き込み、パスを返す
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{"user_id": 1, "authority": 9, "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+// TestVerifyHS256 共通鍵で署名したトークンのクレームを検証する事をテスト
+func TestVerifyHS256(t *testing.T) {
+	v, err := NewVerifier(config.Auth{HMACSecret: string(testSecret), Issuer: "user-service", Audience: "post-service"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs256 := map[string]interface{}{"alg": AlgHS256, "typ": "JWT"}
+	claims := func(update func(c map[string]interface{})) map[string]interface{} {
+		c := validClaims()
+		c["iss"] = "user-service"
+		c["aud"] = []string{"web", "post-service"}
+		update(c)
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  Principal
+		ok    bool
+	}{
+		{"有効", signToken(t, hs256, claims(func(c map[string]interface{}) {}), signHMAC(testSecret)), Principal{UserID: 1, Authority: 9}, true},
+		{"subのユーザーID", signToken(t, hs256, claims(func(c map[string]interface{}) { delete(c, "user_id"); c["sub"] = "42" }), signHMAC(testSecret)), Principal{UserID: 42, Authority: 9}, true},
+		{"audが文字列", signToken(t, hs256, claims(func(c map[string]interface{}) { c["aud"] = "post-service" }), signHMAC(testSecret)), Principal{UserID: 1, Authority: 9}, true},
+		{"期限切れ", signToken(t, hs256, claims(func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Hour).Unix() }), signHMAC(testSecret)), Principal{}, false},
+		{"有効期限なし", signToken(t, hs256, claims(func(c map[string]interface{}) { delete(c, "exp") }), signHMAC(testSecret)), Principal{}, false},
+		{"有効期間前", signToken(t, hs256, claims(func(c map[string]interface{}) { c["nbf"] = time.Now().Add(time.Hour).Unix() }), signHMAC(testSecret)), Principal{}, false},
+		{"発行者が不一致", signToken(t, hs256, claims(func(c map[string]interface{}) { c["iss"] = "other" }), signHMAC(testSecret)), Principal{}, false},
+		{"対象が不一致", signToken(t, hs256, claims(func(c map[string]interface{}) { c["aud"] = "other" }), signHMAC(testSecret)), Principal{}, false},
+		{"ユーザーIDなし", signToken(t, hs256, claims(func(c map[string]interface{}) { delete(c, "user_id") }), signHMAC(testSecret)), Principal{}, false},
+		{"署名の鍵が不一致", signToken(t, hs256, claims(func(c map[string]interface{}) {}), signHMAC([]byte("other"))), Principal{}, false},
+		{"alg=none", signToken(t, map[string]interface{}{"alg": "none"}, claims(func(c map[string]interface{}) {}), func(string) []byte { return nil }), Principal{}, false},
+		{"形式が不正", "token", Principal{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			assert.Equal(t, tt.ok, err == nil)
+			if !tt.ok {
+				assert.Equal(t, true, errors.Is(err, ErrInvalidToken))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestVerifyRS256 公開鍵、JWKSの鍵で署名を検証する事をテスト
+func TestVerifyRS256(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := writeFile(t, "public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	jwksFile := writeFile(t, "jwks.json", []byte(`{"keys":[`+
+		`{"kty":"RSA","kid":"other","use":"sig","alg":"RS256","n":"`+encodeInt(other.N)+`","e":"`+encodeInt(big.NewInt(int64(other.E)))+`"},`+
+		`{"kty":"RSA","kid":"current","use":"sig","alg":"RS256","n":"`+encodeInt(private.N)+`","e":"`+encodeInt(big.NewInt(int64(private.E)))+`"},`+
+		`{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"}]}`))
+
+	// 公開鍵ファイル
+	v, err := NewVerifier(config.Auth{RSAPublicKeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := signToken(t, map[string]interface{}{"alg": AlgRS256}, validClaims(), signRSA(t, private))
+	principal, err := v.Verify(token)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Principal{UserID: 1, Authority: 9}, principal)
+	_, err = v.Verify(signToken(t, map[string]interface{}{"alg": AlgRS256}, validClaims(), signRSA(t, other)))
+	assert.Equal(t, true, errors.Is(err, ErrInvalidToken))
+	// 公開鍵を共通鍵として署名したトークンは受け付けない
+	_, err = v.Verify(signToken(t, map[string]interface{}{"alg": AlgHS256}, validClaims(), signHMAC(der)))
+	assert.Equal(t, true, errors.Is(err, ErrInvalidToken))
+
+	// JWKSはkidが一致する鍵で検証する
+	v, err = NewVerifier(config.Auth{JWKSFile: jwksFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = v.Verify(signToken(t, map[string]interface{}{"alg": AlgRS256, "kid": "current"}, validClaims(), signRSA(t, private)))
+	assert.Equal(t, nil, err)
+	_, err = v.Verify(signToken(t, map[string]interface{}{"alg": AlgRS256, "kid": "other"}, validClaims(), signRSA(t, private)))
+	assert.Equal(t, true, errors.Is(err, ErrInvalidToken))
+	// kidの指定がない場合は全ての鍵で検証する
+	_, err = v.Verify(signToken(t, map[string]interface{}{"alg": AlgRS256}, validClaims(), signRSA(t, private)))
+	assert.Equal(t, nil, err)
+}
+
+// TestNewVerifierInvalid 鍵が指定されない、読み込めない場合にエラーを返す事をテスト
+func TestNewVerifierInvalid(t *testing.T) {
+	_, err := NewVerifier(config.Auth{})
+	assert.NotEqual(t, nil, err)
+	_, err = NewVerifier(config.Auth{RSAPublicKeyFile: writeFile(t, "public.pem", []byte("invalid"))})
+	assert.NotEqual(t, nil, err)
+	_, err = NewVerifier(config.Auth{JWKSFile: writeFile(t, "jwks.json", []byte(`{"keys":[]}`))})
+	assert.NotEqual(t, nil, err)
+}
+
+// TestSignHS256 発行したトークンを検証できる事をテスト
+func TestSignHS256(t *testing.T) {
+	v, err := NewVerifier(config.Auth{HMACSecret: string(testSecret)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := SignHS256(testSecret, Principal{UserID: 3, Authority: 1}, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	principal, err := v.Verify(token)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, Principal{UserID: 3, Authority: 1}, principal)
+}
+
+// TestVerifyMalformed 形式、ヘッダー、クレームが不正なトークンを受け付けない事をテスト
+func TestVerifyMalformed(t *testing.T) {
+	v, err := NewVerifier(config.Auth{HMACSecret: string(testSecret)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs256 := map[string]interface{}{"alg": AlgHS256}
+	// signRaw 符号化済みのヘッダーとクレームに署名する
+	signRaw := func(h string, c string) string {
+		signingInput := h + "." + c
+		return signingInput + "." + base64.RawURLEncoding.EncodeToString(signHMAC(testSecret)(signingInput))
+	}
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	valid := signToken(t, hs256, validClaims(), signHMAC(testSecret))
+	large := validClaims()
+	large["padding"] = strings.Repeat("a", maxTokenLength)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"空", ""},
+		{"区切りが多い", valid + ".e30"},
+		{"alg=none、署名なし", encode(`{"alg":"none"}`) + "." + encode(`{"user_id":1,"exp":4102444800}`) + "."},
+		{"alg=none、共通鍵で署名", signToken(t, map[string]interface{}{"alg": "none"}, validClaims(), signHMAC(testSecret))},
+		{"algの大文字小文字が異なる", signToken(t, map[string]interface{}{"alg": "hs256"}, validClaims(), signHMAC(testSecret))},
+		{"algなし", signToken(t, map[string]interface{}{"typ": "JWT"}, validClaims(), signHMAC(testSecret))},
+		{"未対応のcrit", signToken(t, map[string]interface{}{"alg": AlgHS256, "crit": []string{"exp"}}, validClaims(), signHMAC(testSecret))},
+		{"ヘッダーがbase64urlでない", "!!!." + strings.SplitN(valid, ".", 2)[1]},
+		{"ヘッダーがJSONでない", signRaw(encode("alg"), encode(`{"user_id":1,"exp":4102444800}`))},
+		{"署名がパディング付き", valid[:strings.LastIndex(valid, ".")+1] + base64.URLEncoding.EncodeToString(signHMAC(testSecret)(valid[:strings.LastIndex(valid, ".")]))},
+		{"署名を切り詰め", valid[:len(valid)-2]},
+		{"クレームがJSONでない", signRaw(encode(`{"alg":"HS256"}`), encode("user_id"))},
+		{"expが文字列", signRaw(encode(`{"alg":"HS256"}`), encode(`{"user_id":1,"exp":"4102444800"}`))},
+		{"user_idが負", signRaw(encode(`{"alg":"HS256"}`), encode(`{"user_id":-1,"exp":4102444800}`))},
+		{"subが範囲外", signRaw(encode(`{"alg":"HS256"}`), encode(`{"sub":"4294967296","exp":4102444800}`))},
+		{"大きすぎる", signToken(t, hs256, large, signHMAC(testSecret))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			assert.Equal(t, true, errors.Is(err, ErrInvalidToken))
+			assert.Equal(t, Principal{}, got)
+		})
+	}
+}
+
+// TestVerifyKidMismatch JWKSのkidと鍵の種類が一致しない場合に受け付けない事をテスト
+func TestVerifyKidMismatch(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwksFile := writeFile(t, "jwks.json", []byte(`{"keys":[`+
+		`{"kty":"RSA","kid":"rsa","n":"`+encodeInt(private.N)+`","e":"`+encodeInt(big.NewInt(int64(private.E)))+`"},`+
+		`{"kty":"oct","kid":"hmac","k":"`+base64.RawURLEncoding.EncodeToString(testSecret)+`"}]}`))
+	v, err := NewVerifier(config.Auth{JWKSFile: jwksFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"RS256、kidが一致", signToken(t, map[string]interface{}{"alg": AlgRS256, "kid": "rsa"}, validClaims(), signRSA(t, private)), true},
+		{"HS256、kidが一致", signToken(t, map[string]interface{}{"alg": AlgHS256, "kid": "hmac"}, validClaims(), signHMAC(testSecret)), true},
+		{"存在しないkid", signToken(t, map[string]interface{}{"alg": AlgRS256, "kid": "unknown"}, validClaims(), signRSA(t, private)), false},
+		{"共通鍵のkidでRS256", signToken(t, map[string]interface{}{"alg": AlgRS256, "kid": "hmac"}, validClaims(), signRSA(t, private)), false},
+		{"公開鍵のkidでHS256", signToken(t, map[string]interface{}{"alg": AlgHS256, "kid": "rsa"}, validClaims(), signHMAC(testSecret)), false},
+		{"公開鍵を共通鍵として署名", signToken(t, map[string]interface{}{"alg": AlgHS256, "kid": "rsa"}, validClaims(), signHMAC(private.N.Bytes())), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Verify(tt.token)
+			assert.Equal(t, tt.ok, err == nil)
+		})
+	}
+}
+
+// TestRSAKeySize 短すぎる、大きすぎる公開鍵を読み込まない事をテスト
+func TestRSAKeySize(t *testing.T) {
+	modulus := func(bits int) *big.Int {
+		n := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		return n.Add(n, big.NewInt(1))
+	}
+	tests := []struct {
+		name string
+		bits int
+		ok   bool
+	}{
+		{"1024ビット", 1024, false},
+		{"2048ビット", 2048, true},
+		{"8192ビット", 8192, true},
+		{"8193ビット", 8193, false},
+		{"65536ビット", 65536, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			public := &rsa.PublicKey{N: modulus(tt.bits), E: 65537}
+			_, err := parseJWKS([]byte(`{"keys":[{"kty":"RSA","kid":"key","n":"` + encodeInt(public.N) + `","e":"AQAB"}]}`))
+			assert.Equal(t, tt.ok, err == nil)
+
+			der, err := x509.MarshalPKIXPublicKey(public)
+			if err != nil {
+				t.Fatal(err)
+			}
+			keyFile := writeFile(t, "public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+			_, err = NewVerifier(config.Auth{RSAPublicKeyFile: keyFile})
+			assert.Equal(t, tt.ok, err == nil)
+		})
+	}
+}
+
+// FuzzVerify 任意の文字列を検証しても、パニックせずにErrInvalidTokenか認証したユーザーを返す事をテスト
+func FuzzVerify(f *testing.F) {
+	now := time.Unix(1600000000, 0)
+	v, err := NewVerifier(config.Auth{HMACSecret: string(testSecret)})
+	if err != nil {
+		f.Fatal(err)
+	}
+	v.now = func() time.Time { return now }
+	token, err := SignHS256(testSecret, Principal{UserID: 1, Authority: 9}, now.Add(time.Hour))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seed := range []string{token, token + ".", strings.Replace(token, "eyJhbGciOiJIUzI1NiJ9", "eyJhbGciOiJub25lIn0", 1), "..", ""} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, token string) {
+		principal, err := v.Verify(token)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("got %v, want ErrInvalidToken", err)
+			}
+			return
+		}
+		if principal.UserID == 0 {
+			t.Fatalf("verified token %q without user", token)
+		}
+	})
+}
+
+// FuzzParseJWKS 任意のJWKSを読み込んでも、パニックせずに受け付ける長さの鍵のみを返す事をテスト
+func FuzzParseJWKS(f *testing.F) {
+	f.Add([]byte(`{"keys":[{"kty":"oct","kid":"hmac","k":"c2VjcmV0"}]}`))
+	f.Add([]byte(`{"keys":[{"kty":"RSA","kid":"rsa","n":"AQAB","e":"AQAB"}]}`))
+	f.Add([]byte(`{"keys":[{"kty":"RSA","use":"enc"}]}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return
+		}
+		for _, key := range keys {
+			if key.public != nil && checkRSAKeySize(key.public) != nil {
+				t.Fatalf("accepted RSA key of %d bits", key.public.N.BitLen())
+			}
+		}
+	})
+}
+
+func encodeInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
@@ -2,70 +2,77 @@ package aws
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"log"
 	"math/rand"
-	"os"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/yzmw1213/PostService/config"
+	"github.com/yzmw1213/PostService/usecase/repository"
 )
 
 var (
-	sess               *session.Session
-	awsAccessKey       = os.Getenv("AWS_ACCESS_KEY")
-	bucket             = os.Getenv("AWS_S3_BUCKET_NAME")
-	awsSecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
-	endpoint           = os.Getenv("AWS_S3_ENDPOINT")
-	region             = os.Getenv("AWS_S3_REGION")
-	letters            = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
 )
 
-type downloader struct {
-	bucket string
-	file   string
-	dir    string
-	*s3manager.Downloader
+// S3Storage 画像をS3バケットに保存するImageStorage
+type S3Storage struct {
+	cfg  config.Storage
+	sess *session.Session
 }
 
-//GetS3Session gets session for Connecting to S3
-func GetS3Session() {
-	session, err := NewSession()
+var _ repository.ImageStorage = (*S3Storage)(nil)
+
+// NewS3Storage 設定で指定されたS3バケットに接続するS3Storageを生成する
+func NewS3Storage(cfg config.Storage) (*S3Storage, error) {
+	sess, err := NewSession(cfg)
 	if err != nil {
-		fmt.Println(err)
+		return nil, err
 	}
-	sess = session
+	return &S3Storage{cfg: cfg, sess: sess}, nil
 }
 
 // Upload puts object on S3 bucket specified
-func Upload(imageBase64 string) (string, error) {
-	GetS3Session()
-	// ファイルを開く
+// 保存したオブジェクトのキーを返す
+func (s *S3Storage) Upload(ctx context.Context, imageBase64 string) (string, error) {
 	DATE := time.Now().Format("2006-01-02")
 	NAME := randSeq(15)
 	key := fmt.Sprintf("%s/%s", DATE, NAME)
 
-	uploader := s3manager.NewUploader(sess)
+	uploader := s3manager.NewUploader(s.sess)
 
 	data, _ := base64.StdEncoding.DecodeString(imageBase64)
 	wb := new(bytes.Buffer)
 	wb.Write(data)
 
-	uo, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: &bucket,
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: &s.cfg.Bucket,
 		Key:    &key,
 		Body:   wb,
 	})
 
 	if err != nil {
 		log.Println(err)
+		return "", err
 	}
-	s3Endpoint := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", bucket, region)
 
-	return strings.Replace(uo.Location, s3Endpoint, "", 1), err
+	return key, nil
+}
+
+// Ping 画像を保存するバケットにアクセスできるか検査する
+func (s *S3Storage) Ping(ctx context.Context) error {
+	_, err := s3.New(s.sess).HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: &s.cfg.Bucket})
+	return err
+}
+
+// URL 保存したオブジェクトのキーから、画像のURLを返す
+func (s *S3Storage) URL(key string) string {
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.cfg.Bucket, s.cfg.Region, key)
 }
 
 // randSeq 指定した文字数のランダム文字列を返却
@@ -1,21 +1,37 @@
 package aws
 
 import (
+	"context"
 	"encoding/base64"
 	"os"
 	"testing"
 
+	"github.com/yzmw1213/PostService/config"
 	"gopkg.in/go-playground/assert.v1"
 )
 
+// newTestStorage 環境変数の設定でS3Storageを生成する
+func newTestStorage(t *testing.T) *S3Storage {
+	cfg, _, err := config.Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage, err := NewS3Storage(cfg.Storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return storage
+}
+
 func TestS3Session(t *testing.T) {
-	GetS3Session()
-	if sess == nil {
+	storage := newTestStorage(t)
+	if storage.sess == nil {
 		t.Fatal("AWS SDKからセッションを取得できませんでした")
 	}
 }
 
 func TestUpload(t *testing.T) {
+	storage := newTestStorage(t)
 	file, _ := os.Open("example.jpeg")
 	defer file.Close()
 
@@ -26,7 +42,12 @@ func TestUpload(t *testing.T) {
 	file.Read(data)
 
 	imgBase64 := base64.StdEncoding.EncodeToString(data)
-	location, err := Upload(imgBase64)
+	location, err := storage.Upload(context.Background(), imgBase64)
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, "", location)
 }
+
+func TestURL(t *testing.T) {
+	storage := &S3Storage{cfg: config.Storage{Bucket: "bucket", Region: "ap-northeast-1"}}
+	assert.Equal(t, "https://bucket.s3.ap-northeast-1.amazonaws.com/2021-01-01/key", storage.URL("2021-01-01/key"))
+}
@@ -4,15 +4,16 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/yzmw1213/PostService/config"
 )
 
-// NewSession AWS接続セッションを返す
-func NewSession() (*session.Session, error) {
-	creds := credentials.NewStaticCredentials(awsAccessKey, awsSecretAccessKey, "")
+// NewSession 設定で指定された認証情報でAWS接続セッションを返す
+func NewSession(cfg config.Storage) (*session.Session, error) {
+	creds := credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretAccessKey, "")
 	sess, err := session.NewSession(&aws.Config{
 		Credentials: creds,
-		Region:      aws.String(region),
-		Endpoint:    aws.String(endpoint),
+		Region:      aws.String(cfg.Region),
+		Endpoint:    aws.String(cfg.Endpoint),
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,338 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// MySQLDriver DBにMySQLを指定する値(未指定時のデフォルト)
+	MySQLDriver string = "mysql"
+	// SQLiteDriver DBにSQLiteを指定する値
+	SQLiteDriver string = "sqlite3"
+	// configFileEnv 設定ファイルのパスを指定する環境変数
+	configFileEnv string = "CONFIG_FILE"
+	// configFileFlag 設定ファイルのパスを指定するフラグ
+	configFileFlag string = "config"
+)
+
+// Config 投稿サービスの設定
+type Config struct {
+	Server    Server    `yaml:"server"`
+	DB        DB        `yaml:"db"`
+	Storage   Storage   `yaml:"storage"`
+	User      User      `yaml:"user"`
+	Scheduler Scheduler `yaml:"scheduler"`
+	Health    Health    `yaml:"health"`
+	Auth      Auth      `yaml:"auth"`
+}
+
+// Server gRPCサーバーの設定
+type Server struct {
+	// Address 待ち受けるアドレス
+	Address string `yaml:"address"`
+	// ShutdownTimeout 停止時に処理中のリクエストの完了を待つ時間
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// DB 接続するDBの設定
+type DB struct {
+	// Driver DBの種類(mysql, sqlite3)
+	Driver string `yaml:"driver"`
+	// Address MySQLの接続先(host:port)
+	Address string `yaml:"address"`
+	// Name MySQLのDB名、SQLiteの場合はDBファイルのパス
+	Name     string `yaml:"name"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+}
+
+// Storage 画像を保存するS3の設定
+type Storage struct {
+	AccessKey       string `yaml:"access_key"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	Bucket          string `yaml:"bucket"`
+	Endpoint        string `yaml:"endpoint"`
+	Region          string `yaml:"region"`
+}
+
+// User UserServiceの設定
+type User struct {
+	// URL UserServiceの接続先
+	URL string `yaml:"url"`
+	// CacheTTL 取得したユーザー情報をキャッシュする期間
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+}
+
+// Scheduler 定期実行する処理の設定
+type Scheduler struct {
+	// PublishInterval 予約投稿の公開処理を実行する間隔
+	PublishInterval time.Duration `yaml:"publish_interval"`
+	// TrashRetentionDays ゴミ箱に移動した投稿、コメントを保持する日数
+	TrashRetentionDays int `yaml:"trash_retention_days"`
+}
+
+// Health 依存先の接続を検査するヘルスチェックの設定
+type Health struct {
+	// CheckInterval 依存先の接続を検査する間隔
+	CheckInterval time.Duration `yaml:"check_interval"`
+	// CheckTimeout 依存先1つあたりの検査を打ち切る時間
+	CheckTimeout time.Duration `yaml:"check_timeout"`
+}
+
+// Auth リクエストのトークン(JWT)を検証する鍵の設定
+// HMACSecret、RSAPublicKeyFile、JWKSFileのうち、1つ以上を指定する
+type Auth struct {
+	// HMACSecret HS256の署名を検証する共通鍵
+	HMACSecret string `yaml:"hmac_secret"`
+	// RSAPublicKeyFile RS256の署名を検証する公開鍵(PEM形式)のパス
+	RSAPublicKeyFile string `yaml:"rsa_public_key_file"`
+	// JWKSFile 署名を検証する鍵の一覧(JWKS形式)のパス
+	JWKSFile string `yaml:"jwks_file"`
+	// Issuer 指定した場合は、トークンのissが一致する事を検証する
+	Issuer string `yaml:"issuer"`
+	// Audience 指定した場合は、トークンのaudに含まれる事を検証する
+	Audience string `yaml:"audience"`
+}
+
+// field 設定項目と、値を指定する環境変数、フラグの対応
+type field struct {
+	// key 設定ファイル上の項目名。フラグ名にも使う
+	key   string
+	env   string
+	usage string
+	// value 設定値のポインタを返す
+	value func(c *Config) interface{}
+}
+
+// fields 環境変数、フラグで指定できる設定項目の一覧
+var fields = []field{
+	{"server.address", "LISTEN_ADDRESS", "gRPCサーバーが待ち受けるアドレス", func(c *Config) interface{} { return &c.Server.Address }},
+	{"server.shutdown_timeout", "SHUTDOWN_TIMEOUT", "停止時に処理中のリクエストの完了を待つ時間", func(c *Config) interface{} { return &c.Server.ShutdownTimeout }},
+	{"db.driver", "DB_DRIVER", "DBの種類(mysql, sqlite3)", func(c *Config) interface{} { return &c.DB.Driver }},
+	{"db.address", "DB_ADRESS", "MySQLの接続先(host:port)", func(c *Config) interface{} { return &c.DB.Address }},
+	{"db.name", "DB_NAME", "MySQLのDB名、SQLiteのDBファイルのパス", func(c *Config) interface{} { return &c.DB.Name }},
+	{"db.user", "DB_USER", "MySQLのユーザー名", func(c *Config) interface{} { return &c.DB.User }},
+	{"db.password", "DB_PASSWORD", "MySQLのパスワード", func(c *Config) interface{} { return &c.DB.Password }},
+	{"storage.access_key", "AWS_ACCESS_KEY", "S3のアクセスキー", func(c *Config) interface{} { return &c.Storage.AccessKey }},
+	{"storage.secret_access_key", "AWS_SECRET_ACCESS_KEY", "S3のシークレットアクセスキー", func(c *Config) interface{} { return &c.Storage.SecretAccessKey }},
+	{"storage.bucket", "AWS_S3_BUCKET_NAME", "画像を保存するS3バケット名", func(c *Config) interface{} { return &c.Storage.Bucket }},
+	{"storage.endpoint", "AWS_S3_ENDPOINT", "S3のエンドポイント", func(c *Config) interface{} { return &c.Storage.Endpoint }},
+	{"storage.region", "AWS_S3_REGION", "S3のリージョン", func(c *Config) interface{} { return &c.Storage.Region }},
+	{"user.url", "USER_URL", "UserServiceの接続先", func(c *Config) interface{} { return &c.User.URL }},
+	{"user.cache_ttl", "USER_CACHE_TTL", "ユーザー情報をキャッシュする期間", func(c *Config) interface{} { return &c.User.CacheTTL }},
+	{"scheduler.publish_interval", "PUBLISH_INTERVAL", "予約投稿の公開処理を実行する間隔", func(c *Config) interface{} { return &c.Scheduler.PublishInterval }},
+	{"scheduler.trash_retention_days", "TRASH_RETENTION_DAYS", "ゴミ箱の投稿、コメントを保持する日数", func(c *Config) interface{} { return &c.Scheduler.TrashRetentionDays }},
+	{"health.check_interval", "HEALTH_CHECK_INTERVAL", "依存先の接続を検査する間隔", func(c *Config) interface{} { return &c.Health.CheckInterval }},
+	{"health.check_timeout", "HEALTH_CHECK_TIMEOUT", "依存先1つあたりの検査を打ち切る時間", func(c *Config) interface{} { return &c.Health.CheckTimeout }},
+	{"auth.hmac_secret", "AUTH_HMAC_SECRET", "HS256のトークンを検証する共通鍵", func(c *Config) interface{} { return &c.Auth.HMACSecret }},
+	{"auth.rsa_public_key_file", "AUTH_RSA_PUBLIC_KEY_FILE", "RS256のトークンを検証する公開鍵(PEM形式)のパス", func(c *Config) interface{} { return &c.Auth.RSAPublicKeyFile }},
+	{"auth.jwks_file", "AUTH_JWKS_FILE", "トークンを検証する鍵の一覧(JWKS形式)のパス", func(c *Config) interface{} { return &c.Auth.JWKSFile }},
+	{"auth.issuer", "AUTH_ISSUER", "トークンの発行者(iss)", func(c *Config) interface{} { return &c.Auth.Issuer }},
+	{"auth.audience", "AUTH_AUDIENCE", "トークンの対象(aud)", func(c *Config) interface{} { return &c.Auth.Audience }},
+}
+
+// Default デフォルト値を設定したConfigを返す
+func Default() *Config {
+	return &Config{
+		Server: Server{Address: "0.0.0.0:50053", ShutdownTimeout: 30 * time.Second},
+		DB:     DB{Driver: MySQLDriver},
+		User:   User{CacheTTL: time.Minute},
+		Scheduler: Scheduler{
+			PublishInterval:    time.Minute,
+			TrashRetentionDays: 30,
+		},
+		Health: Health{
+			CheckInterval: 10 * time.Second,
+			CheckTimeout:  3 * time.Second,
+		},
+	}
+}
+
+// Load デフォルト値、設定ファイル、環境変数、フラグの順に上書きした設定を返す
+// 設定ファイルはフラグ-configか環境変数CONFIG_FILEで指定した場合のみ読み込む
+// フラグ以外の引数は2番目の戻り値で返す
+func Load(args []string) (*Config, []string, error) {
+	flags := flag.NewFlagSet("PostService", flag.ContinueOnError)
+	path := flags.String(configFileFlag, "", "YAML形式の設定ファイルのパス")
+	values := map[string]*string{}
+	for _, f := range fields {
+		values[f.key] = flags.String(f.key, "", fmt.Sprintf("%s (環境変数%s)", f.usage, f.env))
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	c := Default()
+	if *path == "" {
+		*path = os.Getenv(configFileEnv)
+	}
+	if *path != "" {
+		if err := c.loadFile(*path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, f := range fields {
+		if raw := os.Getenv(f.env); raw != "" {
+			if err := setValue(f.value(c), raw); err != nil {
+				return nil, nil, fmt.Errorf("環境変数%sの値が不正です: %v", f.env, err)
+			}
+		}
+	}
+
+	var err error
+	flags.Visit(func(fl *flag.Flag) {
+		raw, ok := values[fl.Name]
+		if !ok || err != nil {
+			return
+		}
+		for _, f := range fields {
+			if f.key == fl.Name {
+				if setErr := setValue(f.value(c), *raw); setErr != nil {
+					err = fmt.Errorf("フラグ-%sの値が不正です: %v", f.key, setErr)
+				}
+			}
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, flags.Args(), nil
+}
+
+// loadFile YAML形式の設定ファイルを読み込む。未定義の項目はエラーにする
+func (c *Config) loadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("設定ファイルを読み込めません: %v", err)
+	}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return fmt.Errorf("設定ファイル%sの形式が不正です: %v", path, err)
+	}
+	return nil
+}
+
+// setValue 文字列の設定値を、設定項目の型に変換して設定する
+func setValue(value interface{}, raw string) error {
+	switch v := value.(type) {
+	case *string:
+		*v = raw
+	case *time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		*v = d
+	case *int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		*v = n
+	}
+	return nil
+}
+
+// Validate 設定値を検証し、不足、不正な項目を全てまとめたエラーを返す
+func (c *Config) Validate() error {
+	var problems []string
+	problems = append(problems, c.DB.problems()...)
+	if c.Server.Address == "" {
+		problems = append(problems, required("server.address"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, positive("server.shutdown_timeout"))
+	}
+	if c.Storage.Bucket == "" {
+		problems = append(problems, required("storage.bucket"))
+	}
+	if c.Storage.Region == "" {
+		problems = append(problems, required("storage.region"))
+	}
+	if c.User.URL == "" {
+		problems = append(problems, required("user.url"))
+	}
+	if c.User.CacheTTL <= 0 {
+		problems = append(problems, positive("user.cache_ttl"))
+	}
+	if c.Scheduler.PublishInterval <= 0 {
+		problems = append(problems, positive("scheduler.publish_interval"))
+	}
+	if c.Scheduler.TrashRetentionDays <= 0 {
+		problems = append(problems, positive("scheduler.trash_retention_days"))
+	}
+	if c.Health.CheckInterval <= 0 {
+		problems = append(problems, positive("health.check_interval"))
+	}
+	if c.Health.CheckTimeout <= 0 {
+		problems = append(problems, positive("health.check_timeout"))
+	}
+	if c.Auth.HMACSecret == "" && c.Auth.RSAPublicKeyFile == "" && c.Auth.JWKSFile == "" {
+		problems = append(problems, fmt.Sprintf("%s、%s、%sのいずれかは必須です", describe("auth.hmac_secret"), describe("auth.rsa_public_key_file"), describe("auth.jwks_file")))
+	}
+	return toError(problems)
+}
+
+// Validate DBの設定値を検証する
+// マイグレーションのみ実行する場合はDBの設定だけを検証する
+func (d DB) Validate() error {
+	return toError(d.problems())
+}
+
+// problems DBの設定の不足、不正な項目を返す
+func (d DB) problems() []string {
+	var problems []string
+	switch d.Driver {
+	case MySQLDriver:
+		if d.Address == "" {
+			problems = append(problems, required("db.address"))
+		}
+		if d.Name == "" {
+			problems = append(problems, required("db.name"))
+		}
+		if d.User == "" {
+			problems = append(problems, required("db.user"))
+		}
+	case SQLiteDriver:
+	default:
+		problems = append(problems, fmt.Sprintf("%sは%sか%sを指定してください: %q", describe("db.driver"), MySQLDriver, SQLiteDriver, d.Driver))
+	}
+	return problems
+}
+
+// required 必須項目が未指定の場合のメッセージ
+func required(key string) string {
+	return fmt.Sprintf("%sは必須です", describe(key))
+}
+
+// positive 正の値が必要な項目のメッセージ
+func positive(key string) string {
+	return fmt.Sprintf("%sは正の値を指定してください", describe(key))
+}
+
+// describe 設定項目名と、指定できる環境変数、フラグを返す
+func describe(key string) string {
+	for _, f := range fields {
+		if f.key == key {
+			return fmt.Sprintf("%s(環境変数%s、フラグ-%s)", key, f.env, key)
+		}
+	}
+	return key
+}
+
+// toError メッセージをまとめたエラーを返す
+func toError(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("設定が不正です:\n  " + strings.Join(problems, "\n  "))
+}
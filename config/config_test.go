@@ -0,0 +1,126 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+// setEnv テスト中だけ環境変数を設定する
+func setEnv(t *testing.T, values map[string]string) {
+	for key, value := range values {
+		old, ok := os.LookupEnv(key)
+		os.Setenv(key, value)
+		key := key
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(key, old)
+				return
+			}
+			os.Unsetenv(key)
+		})
+	}
+}
+
+// writeFile テスト用の設定ファイルを作成する
+func writeFile(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "post-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadDefault 未指定の項目はデフォルト値になる事をテスト
+func TestLoadDefault(t *testing.T) {
+	c, args, err := Load(nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(args))
+	assert.Equal(t, "0.0.0.0:50053", c.Server.Address)
+	assert.Equal(t, 30*time.Second, c.Server.ShutdownTimeout)
+	assert.Equal(t, MySQLDriver, c.DB.Driver)
+	assert.Equal(t, time.Minute, c.User.CacheTTL)
+	assert.Equal(t, time.Minute, c.Scheduler.PublishInterval)
+	assert.Equal(t, 30, c.Scheduler.TrashRetentionDays)
+	assert.Equal(t, 10*time.Second, c.Health.CheckInterval)
+	assert.Equal(t, 3*time.Second, c.Health.CheckTimeout)
+}
+
+// TestLoadPrecedence 設定ファイル、環境変数、フラグの順に優先される事をテスト
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, `
+server:
+  address: "file:1"
+db:
+  driver: sqlite3
+  name: file.db
+user:
+  url: file-user:50051
+  cache_ttl: 5m
+scheduler:
+  trash_retention_days: 7
+`)
+	setEnv(t, map[string]string{
+		"CONFIG_FILE":      path,
+		"LISTEN_ADDRESS":   "env:2",
+		"USER_URL":         "env-user:50051",
+		"PUBLISH_INTERVAL": "30s",
+	})
+
+	c, args, err := Load([]string{"-server.address", "flag:3", "migrate", "status"})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"migrate", "status"}, args)
+	assert.Equal(t, "flag:3", c.Server.Address)
+	assert.Equal(t, SQLiteDriver, c.DB.Driver)
+	assert.Equal(t, "file.db", c.DB.Name)
+	assert.Equal(t, "env-user:50051", c.User.URL)
+	assert.Equal(t, 5*time.Minute, c.User.CacheTTL)
+	assert.Equal(t, 30*time.Second, c.Scheduler.PublishInterval)
+	assert.Equal(t, 7, c.Scheduler.TrashRetentionDays)
+}
+
+// TestLoadInvalid 不正な値、未定義の項目はエラーになる事をテスト
+func TestLoadInvalid(t *testing.T) {
+	setEnv(t, map[string]string{"PUBLISH_INTERVAL": "invalid"})
+	_, _, err := Load(nil)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, true, strings.Contains(err.Error(), "PUBLISH_INTERVAL"))
+
+	_, _, err = Load([]string{"-scheduler.trash_retention_days", "seven"})
+	assert.NotEqual(t, nil, err)
+
+	path := writeFile(t, "db:\n  hostname: localhost\n")
+	_, _, err = Load([]string{"-config", path})
+	assert.NotEqual(t, nil, err)
+}
+
+// TestValidate 不足している項目を全てまとめて返す事をテスト
+func TestValidate(t *testing.T) {
+	c := Default()
+	c.Scheduler.TrashRetentionDays = 0
+	err := c.Validate()
+	assert.NotEqual(t, nil, err)
+	for _, name := range []string{"db.address", "DB_NAME", "-db.user", "storage.bucket", "storage.region", "USER_URL", "TRASH_RETENTION_DAYS", "AUTH_HMAC_SECRET", "AUTH_JWKS_FILE"} {
+		assert.Equal(t, true, strings.Contains(err.Error(), name))
+	}
+
+	c.DB = DB{Driver: SQLiteDriver}
+	c.Storage = Storage{Bucket: "bucket", Region: "ap-northeast-1"}
+	c.User.URL = "localhost:50051"
+	c.Scheduler.TrashRetentionDays = 30
+	c.Auth.JWKSFile = "/etc/post-service/jwks.json"
+	assert.Equal(t, nil, c.Validate())
+
+	c.DB.Driver = "postgres"
+	assert.NotEqual(t, nil, c.DB.Validate())
+}
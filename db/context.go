@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+)
+
+// contextConn クエリごとにcontextを渡してSQLを実行する接続
+// gormはcontextを受け取らないため、発行するクエリをcontext付きのメソッドに置き換える
+type contextConn struct {
+	ctx context.Context
+	db  *sql.DB
+}
+
+var _ gorm.SQLCommon = contextConn{}
+
+func (c contextConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(c.ctx, query, args...)
+}
+
+func (c contextConn) Prepare(query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(c.ctx, query)
+}
+
+func (c contextConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(c.ctx, query, args...)
+}
+
+func (c contextConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(c.ctx, query, args...)
+}
+
+// withContext ctxがキャンセルされた時点で実行中のクエリを中断するDB接続を返す
+// 接続プールはDBと共有する
+// gormは共有のDBから接続だけを差し替えたDBを作れないため、gorm.Openで別のDBとして作成する
+// そのため共有のDBに直接行った設定は引き継がれない。ログの設定はLogMode、SetLoggerで、
+// コールバックはCallbackで登録し、両方に適用する。また、DB()はnilを返すため、*sql.DBはGetDB().DB()で取得する
+func withContext(ctx context.Context) *gorm.DB {
+	DB := GetDB()
+	if ctx == nil || ctx.Done() == nil {
+		return DB
+	}
+	sqlDB, ok := DB.CommonDB().(*sql.DB)
+	if !ok {
+		return DB
+	}
+	conn, err := gorm.Open(DB.Dialect().GetName(), contextConn{ctx: ctx, db: sqlDB})
+	if err != nil {
+		return DB
+	}
+	return applyLogSettings(conn)
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/jinzhu/gorm"
+)
+
+// recordLogger 出力されたSQLのログを記録する
+type recordLogger struct {
+	lines []string
+}
+
+func (l *recordLogger) Print(v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprint(v...))
+}
+
+// TestConnWithContextSettings context付きの接続にも、共有のDBと同じログの設定とコールバックが適用される事をテスト
+func TestConnWithContextSettings(t *testing.T) {
+	var queries int64
+	Callback().Query().After("gorm:query").Register("test:count_query", func(scope *gorm.Scope) { atomic.AddInt64(&queries, 1) })
+	defer Callback().Query().Remove("test:count_query")
+	logger := &recordLogger{}
+	SetLogger(logger)
+	defer SetLogger(gorm.Logger{LogWriter: log.New(os.Stdout, "\r\n", 0)})
+	LogMode(true)
+	defer LogMode(false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var rows []schemaMigration
+	assert.Equal(t, nil, createMigrationTables(ctx))
+	logger.lines = nil
+	assert.Equal(t, nil, Conn(ctx).Table(migrationTableName).Find(&rows).Error)
+	assert.Equal(t, nil, GetDB().Table(migrationTableName).Find(&rows).Error)
+
+	assert.Equal(t, int64(2), atomic.LoadInt64(&queries))
+	assert.Equal(t, 2, len(logger.lines))
+}
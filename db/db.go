@@ -1,49 +1,93 @@
 package db
 
 import (
+	"context"
 	"fmt"
-	"os"
-
-	// gormのmysql接続用
-	_ "github.com/jinzhu/gorm/dialects/mysql"
-	"github.com/yzmw1213/PostService/domain/model"
 
 	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/config"
 )
 
 var (
 	// DB データベース構造体
 	DB *gorm.DB
-	// tx トランザクション
-	tx *gorm.DB
 	// PostTableName 投稿サービステーブル名
 	PostTableName string = "posts"
 	// TagTableName タグサービステーブル名
 	TagTableName string = "tags"
+	// postFullTextIndexName 投稿の全文検索インデックス名
+	postFullTextIndexName string = "ft_posts_title_content"
+	// dbConfig Initで指定された接続先の設定
+	dbConfig config.DB
+	// logSettings 共有のDBとcontext付きの接続の両方に適用するログの設定
+	logSettings struct {
+		// ログ出力の有無(nilの場合はgormのデフォルト)
+		enabled *bool
+		logger  Logger
+	}
 )
 
+// Logger SQLのログの出力先
+type Logger interface {
+	Print(v ...interface{})
+}
+
 func initDB() {
-	var err error
-	DBMS := "mysql"
-	DBNAME := os.Getenv("DB_NAME")
-	PASSWORD := os.Getenv("DB_PASSWORD")
-	USER := os.Getenv("DB_USER")
-	PROTOCOL := fmt.Sprintf("tcp(%s)", os.Getenv("DB_ADRESS"))
-	OPTION := "?charset=utf8mb4&parseTime=True&loc=Local"
-	CONNECTION := fmt.Sprintf("%s:%s@%s/%s%s", USER, PASSWORD, PROTOCOL, DBNAME, OPTION)
-
-	_DB, err := gorm.Open(DBMS, CONNECTION)
-	DB = _DB
+	_DB, err := open()
 	if err != nil {
 		panic(err)
 	}
+	DB = applyLogSettings(_DB)
 }
 
-// Init DB接続と、マイグレーションを行う。
-func Init() {
-	initDB()
+// LogMode SQLのログ出力の有無を設定する
+// 共有のDBとcontext付きの接続の両方に適用するため、GetDB().LogModeではなくこちらを使う
+func LogMode(enable bool) {
+	logSettings.enabled = &enable
+	if DB != nil {
+		applyLogSettings(DB)
+	}
+}
+
+// SetLogger SQLのログの出力先を設定する
+// 共有のDBとcontext付きの接続の両方に適用するため、GetDB().SetLoggerではなくこちらを使う
+func SetLogger(logger Logger) {
+	logSettings.logger = logger
+	if DB != nil {
+		applyLogSettings(DB)
+	}
+}
+
+// Callback 共有のDBとcontext付きの接続の両方に適用するコールバックを返す
+// GetDB().Callback()は共有のDBのコールバックを複製して置き換え、context付きの接続に適用されないため使わない
+func Callback() *gorm.Callback {
+	return gorm.DefaultCallback
+}
+
+// applyLogSettings LogMode、SetLoggerで指定されたログの設定を conn に適用する
+func applyLogSettings(conn *gorm.DB) *gorm.DB {
+	if logSettings.logger != nil {
+		conn.SetLogger(logSettings.logger)
+	}
+	if logSettings.enabled != nil {
+		conn.LogMode(*logSettings.enabled)
+	}
+	return conn
+}
+
+// Init 設定で指定されたDBへの接続と、未適用のマイグレーションの適用を行う。
+func Init(cfg config.DB) {
+	Open(cfg)
 	// マイグレーション実行
-	autoMigration()
+	if err := MigrateUp(context.Background()); err != nil {
+		panic(err)
+	}
+}
+
+// Open 設定で指定されたDBに接続する。マイグレーションは適用しない。
+func Open(cfg config.DB) {
+	dbConfig = cfg
+	initDB()
 }
 
 // Close DBと切断する。
@@ -53,11 +97,14 @@ func Close() {
 	}
 }
 
+// Ping DBに接続できるか検査する
+func Ping(ctx context.Context) error {
+	return GetDB().DB().PingContext(ctx)
+}
+
 // GetDB DB接続情報を返す
+// トランザクション内のクエリはConnで取得した接続を使う
 func GetDB() *gorm.DB {
-	if tx != nil {
-		return tx
-	}
 	if DB == nil {
 		initDB()
 	}
@@ -65,29 +112,15 @@ func GetDB() *gorm.DB {
 	return DB
 }
 
-// StartBegin トランザクションを開始する。
-func StartBegin() *gorm.DB {
-	tx = DB.Begin()
-	return tx
-}
-
-// EndRollback トランザクションを終了しロールバックする。
-func EndRollback() {
-	tx.Rollback()
-	tx = nil
-}
-
-// EndCommit トランザクションを終了しコミットする。
-func EndCommit() {
-	tx.Commit()
-	tx = nil
-}
-
-func autoMigration() {
-	fmt.Println("migration")
-	DB.AutoMigrate(&model.Post{})
-	DB.AutoMigrate(&model.Tag{})
-	DB.AutoMigrate(&model.PostTag{})
-	DB.AutoMigrate(&model.PostLikeUser{})
-	DB.AutoMigrate(&model.Comment{})
+// createFullTextIndex 投稿の件名、内容に日本語対応(ngram)の全文検索インデックスを作成する
+// 全文検索インデックスはMySQLのみ対応する
+func createFullTextIndex(tx *gorm.DB) error {
+	if IsSQLite() {
+		return nil
+	}
+	if tx.Dialect().HasIndex(PostTableName, postFullTextIndexName) {
+		return nil
+	}
+	query := fmt.Sprintf("ALTER TABLE %s ADD FULLTEXT INDEX %s (title, content) WITH PARSER ngram", PostTableName, postFullTextIndexName)
+	return tx.Exec(query).Error
 }
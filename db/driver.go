@@ -0,0 +1,112 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/jinzhu/gorm"
+	// gormのmysql接続用
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+	// gormのsqlite接続用
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/mattn/go-sqlite3"
+	"github.com/yzmw1213/PostService/config"
+)
+
+const (
+	// MySQLDriver 設定のdb.driverにMySQLを指定する値(未指定時のデフォルト)
+	MySQLDriver string = config.MySQLDriver
+	// SQLiteDriver 設定のdb.driverにSQLiteを指定する値
+	SQLiteDriver string = config.SQLiteDriver
+	// defaultSQLitePath SQLite利用時にdb.nameが未指定の場合のファイル
+	defaultSQLitePath string = "post.db"
+	// sqliteDriverName MySQL互換の関数を登録したSQLiteのドライバ名
+	sqliteDriverName string = "sqlite3_post"
+)
+
+func init() {
+	sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{ConnectHook: registerSQLiteFunctions})
+}
+
+// Driver 設定で指定されたDBの種類を返す
+func Driver() string {
+	if dbConfig.Driver != "" {
+		return dbConfig.Driver
+	}
+	return MySQLDriver
+}
+
+// IsSQLite 接続先がSQLiteか判定する
+func IsSQLite() bool {
+	return GetDB().Dialect().GetName() == SQLiteDriver
+}
+
+// open 設定で指定されたDBに接続する
+func open() (*gorm.DB, error) {
+	switch Driver() {
+	case MySQLDriver:
+		DBNAME := dbConfig.Name
+		PASSWORD := dbConfig.Password
+		USER := dbConfig.User
+		PROTOCOL := fmt.Sprintf("tcp(%s)", dbConfig.Address)
+		OPTION := "?charset=utf8mb4&parseTime=True&loc=Local"
+		CONNECTION := fmt.Sprintf("%s:%s@%s/%s%s", USER, PASSWORD, PROTOCOL, DBNAME, OPTION)
+		return gorm.Open(MySQLDriver, CONNECTION)
+	case SQLiteDriver:
+		path := dbConfig.Name
+		if path == "" {
+			path = defaultSQLitePath
+		}
+		// SQLiteは接続ごとに外部キー制約を有効にする必要がある
+		sqlDB, err := sql.Open(sqliteDriverName, "file:"+path+"?_foreign_keys=1")
+		if err != nil {
+			return nil, err
+		}
+		// SQLiteは書き込みをファイル単位でロックするため、接続を1つに絞って直列に実行する
+		sqlDB.SetMaxOpenConns(1)
+		return gorm.Open(SQLiteDriver, sqlDB)
+	}
+	return nil, fmt.Errorf("unsupported db.driver: %s", Driver())
+}
+
+// ElapsedSecondsSQL column の日時から、プレースホルダで渡す日時までの経過秒数を求める式を返す
+func ElapsedSecondsSQL(column string) string {
+	if IsSQLite() {
+		return fmt.Sprintf("(CAST(strftime('%%s', ?) AS INTEGER) - CAST(strftime('%%s', %s) AS INTEGER))", column)
+	}
+	return fmt.Sprintf("TIMESTAMPDIFF(SECOND, %s, ?)", column)
+}
+
+// registerSQLiteFunctions SQLiteにないMySQLの数学関数を登録する
+func registerSQLiteFunctions(conn *sqlite3.SQLiteConn) error {
+	functions := map[string]interface{}{
+		"pow": func(x interface{}, y interface{}) float64 {
+			return math.Pow(toFloat(x), toFloat(y))
+		},
+		// MySQLと同じく整数を返し、CAST AS SIGNEDの結果が整数になるようにする
+		"floor": func(x interface{}) int64 {
+			return int64(math.Floor(toFloat(x)))
+		},
+		"greatest": func(x interface{}, y interface{}) float64 {
+			return math.Max(toFloat(x), toFloat(y))
+		},
+	}
+	for name, impl := range functions {
+		if err := conn.RegisterFunc(name, impl, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toFloat SQLiteの数値をfloat64に変換する
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
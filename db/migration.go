@@ -0,0 +1,253 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	// migrationTableName 適用済みのマイグレーションを記録するテーブル名
+	migrationTableName string = "schema_migrations"
+	// migrationLockTableName マイグレーション実行中のロックを表すテーブル名
+	migrationLockTableName string = "schema_migrations_lock"
+	// migrationLockID ロック行のID(1行のみ使う)
+	migrationLockID uint32 = 1
+	// migrationLockTimeout ロックを取得できるまで待つ時間
+	migrationLockTimeout = time.Minute
+	// migrationLockRetryInterval ロックの取得を再試行する間隔
+	migrationLockRetryInterval = time.Second
+	// migrationLockExpiry 最後に更新されてから経過したロックを、異常終了で残ったものとして解除するまでの時間
+	migrationLockExpiry = 10 * time.Minute
+	// migrationLockHeartbeat マイグレーション中にロックの取得日時を更新する間隔(migrationLockExpiryより短くする)
+	migrationLockHeartbeat = time.Minute
+)
+
+var (
+	// ErrMigrationLocked 他のプロセスがマイグレーション中で、ロックを取得できなかった時のエラー
+	ErrMigrationLocked = errors.New("migration is locked by another process")
+	// ErrNoMigrationToRollback 取り消せるマイグレーションがない時のエラー
+	ErrNoMigrationToRollback = errors.New("no migration to roll back")
+)
+
+// Migration 番号付きのスキーマ変更
+// Upで変更を適用し、Downで適用前の状態に戻す
+type Migration struct {
+	Version uint32
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// MigrationStatus マイグレーション1件の適用状況
+type MigrationStatus struct {
+	Version uint32
+	Name    string
+	// 適用日時(未適用の場合はnil)
+	AppliedAt *time.Time
+}
+
+// schemaMigration schema_migrationsテーブルの1行
+type schemaMigration struct {
+	Version   uint32
+	Name      string
+	AppliedAt time.Time
+}
+
+// MigrateUp 未適用のマイグレーションを番号順に全て適用する
+func MigrateUp(ctx context.Context) error {
+	return withMigrationLock(ctx, func() error {
+		applied, err := appliedMigrations(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range sortedMigrations() {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			log.Printf("migration %d_%s up\n", m.Version, m.Name)
+			err := Transaction(ctx, func(ctx context.Context) error {
+				tx := Conn(ctx)
+				if err := m.Up(tx); err != nil {
+					return err
+				}
+				return tx.Table(migrationTableName).Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %v", m.Version, m.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// MigrateDown 最後に適用したマイグレーションを1件取り消す
+func MigrateDown(ctx context.Context) error {
+	return withMigrationLock(ctx, func() error {
+		applied, err := appliedMigrations(ctx)
+		if err != nil {
+			return err
+		}
+		migrations := sortedMigrations()
+		for n := len(migrations) - 1; n >= 0; n-- {
+			m := migrations[n]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			log.Printf("migration %d_%s down\n", m.Version, m.Name)
+			err := Transaction(ctx, func(ctx context.Context) error {
+				tx := Conn(ctx)
+				if err := m.Down(tx); err != nil {
+					return err
+				}
+				return tx.Table(migrationTableName).Where("version = ?", m.Version).Delete(&schemaMigration{}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down: %v", m.Version, m.Name, err)
+			}
+			return nil
+		}
+		return ErrNoMigrationToRollback
+	})
+}
+
+// MigrationStatuses 全マイグレーションの適用状況を番号順に返す
+func MigrationStatuses(ctx context.Context) ([]MigrationStatus, error) {
+	if err := createMigrationTables(ctx); err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []MigrationStatus
+	for _, m := range sortedMigrations() {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if row, ok := applied[m.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// sortedMigrations 登録されたマイグレーションを番号順に返す
+func sortedMigrations() []Migration {
+	sorted := append([]Migration{}, migrations...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Version < sorted[b].Version })
+	return sorted
+}
+
+// appliedMigrations 適用済みのマイグレーションを番号をキーにして返す
+func appliedMigrations(ctx context.Context) (map[uint32]schemaMigration, error) {
+	var rows []schemaMigration
+	if err := Conn(ctx).Table(migrationTableName).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := map[uint32]schemaMigration{}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// createMigrationTables マイグレーションの管理に使うテーブルを作成する
+func createMigrationTables(ctx context.Context) error {
+	DB := Conn(ctx)
+	queries := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version %s NOT NULL PRIMARY KEY, name varchar(255) NOT NULL, applied_at datetime NOT NULL)", migrationTableName, uintColumnType()),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id %s NOT NULL PRIMARY KEY, locked_at datetime NOT NULL)", migrationLockTableName, uintColumnType()),
+	}
+	for _, query := range queries {
+		if err := DB.Exec(query).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withMigrationLock ロックを取得してfnを実行する
+// 複数のレプリカが同時に起動しても、マイグレーションは1つのプロセスだけが実行する
+func withMigrationLock(ctx context.Context, fn func() error) error {
+	if err := createMigrationTables(ctx); err != nil {
+		return err
+	}
+	if err := acquireMigrationLock(ctx); err != nil {
+		return err
+	}
+	defer func() {
+		// 呼び出し元のcontextがキャンセルされていてもロックは解除する
+		err := GetDB().Table(migrationLockTableName).Where("id = ?", migrationLockID).Delete(&struct{}{}).Error
+		if err != nil {
+			log.Printf("failed to release migration lock: %v\n", err)
+		}
+	}()
+	stop := keepMigrationLock(migrationLockHeartbeat)
+	defer stop()
+	return fn()
+}
+
+// keepMigrationLock 停止するまで、interval毎にロックの取得日時を更新する
+// 返り値の関数で停止し、実行中の更新の完了を待つ
+func keepMigrationLock(interval time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				err := GetDB().Exec(fmt.Sprintf("UPDATE %s SET locked_at = ? WHERE id = ?", migrationLockTableName), time.Now(), migrationLockID).Error
+				if err != nil {
+					log.Printf("failed to refresh migration lock: %v\n", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// acquireMigrationLock ロック行を登録できるまで再試行する
+// 異常終了で残ったロックは、一定時間経過後に解除してから取得する
+func acquireMigrationLock(ctx context.Context) error {
+	deadline := time.Now().Add(migrationLockTimeout)
+	for {
+		DB := Conn(ctx)
+		if err := DB.Exec(fmt.Sprintf("DELETE FROM %s WHERE locked_at < ?", migrationLockTableName), time.Now().Add(-migrationLockExpiry)).Error; err != nil {
+			return err
+		}
+		err := DB.Exec(fmt.Sprintf("INSERT INTO %s (id, locked_at) VALUES (?, ?)", migrationLockTableName), migrationLockID, time.Now()).Error
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrMigrationLocked
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(migrationLockRetryInterval):
+		}
+	}
+}
+
+// uintColumnType uint32の列の型を返す
+func uintColumnType() string {
+	if IsSQLite() {
+		return "integer"
+	}
+	return "int unsigned"
+}
@@ -0,0 +1,222 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/config"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// TestMain 一時ディレクトリのSQLiteでテストを実行する
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "post-db")
+	if err != nil {
+		panic(err)
+	}
+	Open(config.DB{Driver: SQLiteDriver, Name: filepath.Join(dir, "test.db")})
+
+	code := m.Run()
+	Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// resetSchema 全テーブルを削除する
+func resetSchema(t *testing.T) {
+	tables := []string{"comments", "post_like_users", "post_tags", "post_revision_tags", "post_revisions", TagTableName, PostTableName, migrationTableName, migrationLockTableName}
+	for _, table := range tables {
+		if err := GetDB().Exec("DROP TABLE IF EXISTS " + table).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestMigrateUpDown 適用、取り消しを繰り返せる事をテスト
+func TestMigrateUpDown(t *testing.T) {
+	resetSchema(t)
+	ctx := context.Background()
+
+	statuses, err := MigrationStatuses(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, len(migrations), len(statuses))
+	assert.Equal(t, true, statuses[0].AppliedAt == nil)
+
+	assert.Equal(t, nil, MigrateUp(ctx))
+	assert.Equal(t, true, GetDB().HasTable("post_tags"))
+	statuses, err = MigrationStatuses(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, statuses[0].AppliedAt != nil)
+
+	// 適用済みの場合は何もしない
+	assert.Equal(t, nil, MigrateUp(ctx))
+
+	for range migrations {
+		assert.Equal(t, nil, MigrateDown(ctx))
+	}
+	assert.Equal(t, false, GetDB().HasTable("post_tags"))
+	assert.Equal(t, ErrNoMigrationToRollback, MigrateDown(ctx))
+
+	assert.Equal(t, nil, MigrateUp(ctx))
+	assert.Equal(t, true, GetDB().HasTable("post_tags"))
+}
+
+// legacySchema 以前の起動時のAutoMigrateで作成されたテーブル
+// 紐付けテーブルは主キー、外部キーがなく、commentsにはversion、deleted_atがない
+var legacySchema = []string{
+	"CREATE TABLE posts (id integer PRIMARY KEY AUTOINCREMENT, title varchar(255), content varchar(255), image varchar(255), create_user_id integer, update_user_id integer, created_at datetime, updated_at datetime)",
+	"CREATE TABLE tags (id integer PRIMARY KEY AUTOINCREMENT, tag_name varchar(255), create_user_id integer, update_user_id integer, status integer, created_at datetime, updated_at datetime)",
+	"CREATE TABLE post_tags (post_id integer, tag_id integer)",
+	"CREATE TABLE post_like_users (post_id integer, user_id integer)",
+	"CREATE TABLE comments (comment_id integer PRIMARY KEY AUTOINCREMENT, post_id integer, create_user_id integer, comment_content varchar(255), created_at datetime, updated_at datetime)",
+}
+
+// createLegacySchema 以前のスキーマのテーブルを作成し、queriesで行を登録する
+func createLegacySchema(t *testing.T, queries ...string) {
+	for _, query := range append(legacySchema, queries...) {
+		if err := GetDB().Exec(query).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestMigrateLegacySchema AutoMigrateで作成したDBの紐付けを、重複と参照先のない行を除いて移す事をテスト
+func TestMigrateLegacySchema(t *testing.T) {
+	resetSchema(t)
+	ctx := context.Background()
+	DB := GetDB()
+	createLegacySchema(t,
+		"INSERT INTO posts (id, title, content, create_user_id, created_at, updated_at) VALUES (1, 'title', 'content', 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+		"INSERT INTO tags (id, tag_name, create_user_id, status, created_at, updated_at) VALUES (1, 'tag', 1, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+		"INSERT INTO post_tags (post_id, tag_id) VALUES (1, 1), (1, 1), (1, 2)",
+		"INSERT INTO post_like_users (post_id, user_id) VALUES (1, 2), (2, 2)",
+		"INSERT INTO comments (post_id, create_user_id, comment_content, created_at, updated_at) VALUES (1, 2, 'comment', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP), (2, 2, 'orphan', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+	)
+	post := model.Post{ID: 1}
+	tag := model.Tag{ID: 1}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	err := MigrateUp(ctx)
+	log.SetOutput(os.Stderr)
+	assert.Equal(t, nil, err)
+	// 移さなかった行はテーブル毎にログに出力する
+	assert.Equal(t, true, strings.Contains(logs.String(), "post_tags: dropped 2 of 3 rows (1 without referenced row, 1 duplicated)"))
+	assert.Equal(t, true, strings.Contains(logs.String(), "post_like_users: dropped 1 of 2 rows"))
+	assert.Equal(t, true, strings.Contains(logs.String(), "comments: dropped 1 of 2 rows"))
+
+	count := func(value interface{}) int {
+		var n int
+		DB.Model(value).Count(&n)
+		return n
+	}
+	assert.Equal(t, 1, count(&model.PostTag{}))
+	assert.Equal(t, 1, count(&model.PostLikeUser{}))
+	assert.Equal(t, 1, count(&model.Comment{}))
+	// 以前のテーブルにない列はデフォルト値になる
+	var comment model.Comment
+	assert.Equal(t, nil, DB.First(&comment).Error)
+	assert.Equal(t, "comment", comment.CommentContent)
+	assert.Equal(t, uint32(1), comment.Version)
+
+	// 同じ紐付けは登録できない
+	err = DB.Create(&model.PostTag{PostID: post.ID, TagID: tag.ID}).Error
+	assert.NotEqual(t, nil, err)
+	// 存在しない投稿には紐付けられない
+	err = DB.Create(&model.PostLikeUser{PostID: post.ID + 1, UserID: 3}).Error
+	assert.NotEqual(t, nil, err)
+	// 投稿を物理削除すると紐付けも削除される
+	DB.Unscoped().Delete(&post)
+	assert.Equal(t, 0, count(&model.PostTag{}))
+	assert.Equal(t, 0, count(&model.PostLikeUser{}))
+	assert.Equal(t, 0, count(&model.Comment{}))
+}
+
+// TestMigrateExistingTables 以前のスキーマのテーブルに不足している列とインデックスを追加する事をテスト
+func TestMigrateExistingTables(t *testing.T) {
+	resetSchema(t)
+	ctx := context.Background()
+	DB := GetDB()
+	DB.Exec("CREATE TABLE posts (id integer NOT NULL PRIMARY KEY AUTOINCREMENT, status integer NOT NULL DEFAULT 2, title varchar(255), content varchar(255), create_user_id integer)")
+	DB.Exec("INSERT INTO posts (title, content, create_user_id) VALUES ('title', 'content', 1)")
+
+	assert.Equal(t, nil, MigrateUp(ctx))
+	for _, column := range []string{"version", "publish_at", "deleted_at"} {
+		assert.Equal(t, true, DB.Dialect().HasColumn(PostTableName, column))
+	}
+	assert.Equal(t, true, DB.Dialect().HasIndex(PostTableName, "idx_posts_create_user_id"))
+	assert.Equal(t, true, DB.Dialect().HasIndex("post_revisions", "idx_post_revisions_post_id_revision"))
+
+	var post model.Post
+	assert.Equal(t, nil, DB.First(&post).Error)
+	assert.Equal(t, uint32(1), post.Version)
+}
+
+// TestMigrateResume 紐付けテーブルの作り直しが途中で失敗した後も、再実行できる事をテスト
+func TestMigrateResume(t *testing.T) {
+	resetSchema(t)
+	ctx := context.Background()
+	DB := GetDB()
+	createLegacySchema(t,
+		"INSERT INTO posts (id, title, content, create_user_id) VALUES (1, 'title', 'content', 1)",
+		"INSERT INTO post_like_users (post_id, user_id) VALUES (1, 2)",
+	)
+	// 入れ替え前に失敗した場合と、入れ替え後に失敗した場合に残るテーブル
+	DB.Exec("CREATE TABLE post_like_users_new (post_id integer, user_id integer)")
+	DB.Exec("CREATE TABLE comments_old (comment_id integer)")
+
+	assert.Equal(t, nil, MigrateUp(ctx))
+	assert.Equal(t, false, DB.HasTable("post_like_users_new"))
+	assert.Equal(t, false, DB.HasTable("comments_old"))
+	var n int
+	DB.Model(&model.PostLikeUser{}).Count(&n)
+	assert.Equal(t, 1, n)
+}
+
+// TestMigrationLock 他のプロセスがロックを取得している間は実行しない事をテスト
+func TestMigrationLock(t *testing.T) {
+	resetSchema(t)
+	ctx := context.Background()
+	assert.Equal(t, nil, createMigrationTables(ctx))
+	assert.Equal(t, nil, acquireMigrationLock(ctx))
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, MigrateUp(timeoutCtx))
+	assert.Equal(t, false, GetDB().HasTable("post_tags"))
+
+	// 一定時間経過したロックは異常終了で残ったものとして解除する
+	GetDB().Exec("UPDATE "+migrationLockTableName+" SET locked_at = ?", time.Now().Add(-migrationLockExpiry-time.Minute))
+	assert.Equal(t, nil, MigrateUp(ctx))
+	assert.Equal(t, true, GetDB().HasTable("post_tags"))
+}
+
+// TestKeepMigrationLock マイグレーション中はロックの取得日時を更新する事をテスト
+func TestKeepMigrationLock(t *testing.T) {
+	resetSchema(t)
+	ctx := context.Background()
+	assert.Equal(t, nil, createMigrationTables(ctx))
+	assert.Equal(t, nil, acquireMigrationLock(ctx))
+	expired := time.Now().Add(-migrationLockExpiry - time.Minute)
+	GetDB().Exec("UPDATE "+migrationLockTableName+" SET locked_at = ?", expired)
+
+	stop := keepMigrationLock(10 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	stop()
+
+	var lock struct{ LockedAt time.Time }
+	assert.Equal(t, nil, GetDB().Table(migrationLockTableName).Where("id = ?", migrationLockID).Scan(&lock).Error)
+	assert.Equal(t, true, lock.LockedAt.After(expired))
+	// 更新されたロックは解除されない
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, MigrateUp(timeoutCtx))
+}
@@ -0,0 +1,347 @@
+package db
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// migrations 適用するマイグレーションの一覧
+// 適用済みのマイグレーションは変更せず、変更は新しい番号で追加する
+// モデルの変更に影響されないよう、スキーマはAutoMigrateを使わずDDLで定義する
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "initial_schema",
+		Up:      initialSchemaUp,
+		Down:    initialSchemaDown,
+	},
+}
+
+// tableColumn テーブルの列
+type tableColumn struct {
+	name string
+	// 型、NULL制約、既定値
+	definition string
+}
+
+// tableIndex テーブルのインデックス
+type tableIndex struct {
+	name    string
+	columns string
+	unique  bool
+}
+
+// tableSchema テーブルの定義
+type tableSchema struct {
+	name    string
+	columns []tableColumn
+	// 主キー、外部キー
+	constraints []string
+	indexes     []tableIndex
+	// 作り直す前のテーブルから移す行の条件(紐付けテーブルのみ。参照先が存在しない行は移さない)
+	condition string
+}
+
+// baseTables posts、tags、post_revisions、post_revision_tagsの定義
+// 更新履歴は投稿の完全削除時にまとめて削除し、参照先のない行は整合性の検査で検出する
+func baseTables() []tableSchema {
+	uintType := uintColumnType()
+	return []tableSchema{
+		{
+			name: PostTableName,
+			columns: []tableColumn{
+				{"id", uintType + " NOT NULL PRIMARY KEY" + autoIncrement()},
+				{"status", uintType + " NOT NULL DEFAULT 2"},
+				{"title", "varchar(255) NOT NULL DEFAULT ''"},
+				{"content", "varchar(255) NOT NULL DEFAULT ''"},
+				{"image", "varchar(255) NOT NULL DEFAULT ''"},
+				{"create_user_id", uintType + " NOT NULL DEFAULT 0"},
+				{"update_user_id", uintType + " NOT NULL DEFAULT 0"},
+				{"version", uintType + " NOT NULL DEFAULT 1"},
+				{"publish_at", "datetime NULL"},
+				{"created_at", "datetime NULL"},
+				{"updated_at", "datetime NULL"},
+				{"deleted_at", "datetime NULL"},
+			},
+			indexes: []tableIndex{
+				{name: "idx_posts_status_publish_at", columns: "status, publish_at"},
+				{name: "idx_posts_create_user_id", columns: "create_user_id"},
+				{name: "idx_posts_deleted_at", columns: "deleted_at"},
+			},
+		},
+		{
+			name: TagTableName,
+			columns: []tableColumn{
+				{"id", uintType + " NOT NULL PRIMARY KEY" + autoIncrement()},
+				{"tag_name", "varchar(255) NOT NULL DEFAULT ''"},
+				{"create_user_id", uintType + " NOT NULL DEFAULT 0"},
+				{"update_user_id", uintType + " NOT NULL DEFAULT 0"},
+				{"status", uintType + " NOT NULL DEFAULT 0"},
+				{"created_at", "datetime NULL"},
+				{"updated_at", "datetime NULL"},
+			},
+			indexes: []tableIndex{
+				{name: "idx_tags_tag_name", columns: "tag_name"},
+				{name: "idx_tags_status", columns: "status"},
+			},
+		},
+		{
+			name: "post_revisions",
+			columns: []tableColumn{
+				{"id", uintType + " NOT NULL PRIMARY KEY" + autoIncrement()},
+				{"post_id", uintType + " NOT NULL DEFAULT 0"},
+				{"revision", uintType + " NOT NULL DEFAULT 0"},
+				{"title", "varchar(255) NOT NULL DEFAULT ''"},
+				{"content", "varchar(255) NOT NULL DEFAULT ''"},
+				{"update_user_id", uintType + " NOT NULL DEFAULT 0"},
+				{"created_at", "datetime NULL"},
+			},
+			indexes: []tableIndex{
+				{name: "idx_post_revisions_post_id_revision", columns: "post_id, revision", unique: true},
+			},
+		},
+		{
+			name: "post_revision_tags",
+			columns: []tableColumn{
+				{"revision_id", uintType + " NOT NULL DEFAULT 0"},
+				{"tag_id", uintType + " NOT NULL DEFAULT 0"},
+			},
+			constraints: []string{"PRIMARY KEY (revision_id, tag_id)"},
+		},
+	}
+}
+
+// relationTables post_tags、post_like_users、commentsの定義
+// 投稿、タグが物理削除された場合は紐付けも削除する
+// 外部キー名は作り直しの途中で失敗した場合に重複しないよう、DBに採番させる
+func relationTables() []tableSchema {
+	uintType := uintColumnType()
+	return []tableSchema{
+		{
+			name: "post_tags",
+			columns: []tableColumn{
+				{"post_id", uintType + " NOT NULL"},
+				{"tag_id", uintType + " NOT NULL"},
+			},
+			constraints: []string{
+				"PRIMARY KEY (post_id, tag_id)",
+				"FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE",
+				"FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE",
+			},
+			indexes:   []tableIndex{{name: "idx_post_tags_tag_id", columns: "tag_id"}},
+			condition: "post_id IN (SELECT id FROM posts) AND tag_id IN (SELECT id FROM tags)",
+		},
+		{
+			name: "post_like_users",
+			columns: []tableColumn{
+				{"post_id", uintType + " NOT NULL"},
+				{"user_id", uintType + " NOT NULL"},
+			},
+			constraints: []string{
+				"PRIMARY KEY (post_id, user_id)",
+				"FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE",
+			},
+			indexes:   []tableIndex{{name: "idx_post_like_users_user_id", columns: "user_id"}},
+			condition: "post_id IN (SELECT id FROM posts)",
+		},
+		{
+			name: "comments",
+			columns: []tableColumn{
+				{"comment_id", uintType + " NOT NULL PRIMARY KEY" + autoIncrement()},
+				{"post_id", uintType + " NOT NULL"},
+				{"create_user_id", uintType + " NOT NULL"},
+				{"comment_content", "varchar(255) NOT NULL"},
+				{"version", uintType + " NOT NULL DEFAULT 1"},
+				{"created_at", "datetime NULL"},
+				{"updated_at", "datetime NULL"},
+				{"deleted_at", "datetime NULL"},
+			},
+			constraints: []string{
+				"FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE",
+			},
+			indexes: []tableIndex{
+				{name: "idx_comments_post_id_deleted_at", columns: "post_id, deleted_at"},
+				{name: "idx_comments_create_user_id", columns: "create_user_id"},
+				{name: "idx_comments_deleted_at", columns: "deleted_at"},
+			},
+			condition: "post_id IN (SELECT id FROM posts)",
+		},
+	}
+}
+
+// initialSchemaUp 全テーブルを作成する
+// 以前の起動時のAutoMigrateで作成済みのDBでは、不足している列とインデックスを追加し、
+// 紐付けテーブルは主キー、外部キーを定義したテーブルに作り直す
+func initialSchemaUp(tx *gorm.DB) error {
+	for _, table := range baseTables() {
+		if err := createOrUpgradeTable(tx, table); err != nil {
+			return err
+		}
+	}
+	if err := createFullTextIndex(tx); err != nil {
+		return err
+	}
+	for _, table := range relationTables() {
+		if err := rebuildRelationTable(tx, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initialSchemaDown 全テーブルを削除する
+func initialSchemaDown(tx *gorm.DB) error {
+	tables := []string{"comments", "post_like_users", "post_tags", "post_revision_tags", "post_revisions", TagTableName, PostTableName}
+	for _, table := range tables {
+		if err := tx.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", table)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createOrUpgradeTable テーブルを定義通りに作成する
+// 既にテーブルがある場合は、不足している列とインデックスのみ追加する
+func createOrUpgradeTable(tx *gorm.DB, table tableSchema) error {
+	if !tx.HasTable(table.name) {
+		if err := createTable(tx, table.name, table); err != nil {
+			return err
+		}
+		return createIndexes(tx, table)
+	}
+	for _, column := range table.columns {
+		if tx.Dialect().HasColumn(table.name, column.name) {
+			continue
+		}
+		// 既存の行があるため、主キー以外の列のみ追加する
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table.name, column.name, column.definition)
+		if err := tx.Exec(query).Error; err != nil {
+			return err
+		}
+	}
+	return createIndexes(tx, table)
+}
+
+// rebuildRelationTable テーブルを定義通りに作成する
+// 既にテーブルがある場合は、<table>_newに行を移してから入れ替え、元のテーブルを削除する
+// MySQLのDDLはトランザクション内でも個別に確定するため、途中で失敗した場合は次回の実行で残ったテーブルを削除してやり直す
+func rebuildRelationTable(tx *gorm.DB, table tableSchema) error {
+	newName := table.name + "_new"
+	oldName := table.name + "_old"
+	// 前回の実行で残ったテーブル。入れ替え前に失敗した場合は<table>_new、入れ替え後に失敗した場合は<table>_oldが残る
+	for _, name := range []string{newName, oldName} {
+		if err := tx.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", name)).Error; err != nil {
+			return err
+		}
+	}
+	if !tx.HasTable(table.name) {
+		if err := createTable(tx, table.name, table); err != nil {
+			return err
+		}
+		return createIndexes(tx, table)
+	}
+
+	if err := createTable(tx, newName, table); err != nil {
+		return err
+	}
+	if err := copyRelationRows(tx, table, newName); err != nil {
+		return err
+	}
+	if err := swapTables(tx, table.name, newName, oldName); err != nil {
+		return err
+	}
+	if err := tx.Exec(fmt.Sprintf("DROP TABLE %s", oldName)).Error; err != nil {
+		return err
+	}
+	// SQLiteのインデックス名はDB全体で一意のため、元のテーブルを削除してから作成する
+	return createIndexes(tx, table)
+}
+
+// copyRelationRows 作り直す前のテーブルから、重複と参照先のない行を除いて新しいテーブルに移す
+// 作り直す前のテーブルにない列(以前のcommentsのversion、deleted_atなど)は移さず、新しいテーブルのデフォルト値とする
+// 移さなかった行の件数はログに出力する
+func copyRelationRows(tx *gorm.DB, table tableSchema, newName string) error {
+	var columns []string
+	for _, column := range table.columns {
+		if !tx.Dialect().HasColumn(table.name, column.name) {
+			continue
+		}
+		columns = append(columns, column.name)
+	}
+	joined := strings.Join(columns, ", ")
+
+	var total, orphaned, copied int
+	if err := tx.Table(table.name).Count(&total).Error; err != nil {
+		return err
+	}
+	if err := tx.Table(table.name).Where("NOT (" + table.condition + ")").Count(&orphaned).Error; err != nil {
+		return err
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) SELECT DISTINCT %s FROM %s WHERE %s", newName, joined, joined, table.name, table.condition)
+	if err := tx.Exec(query).Error; err != nil {
+		return err
+	}
+	if err := tx.Table(newName).Count(&copied).Error; err != nil {
+		return err
+	}
+	if copied < total {
+		log.Printf("migration: %s: dropped %d of %d rows (%d without referenced row, %d duplicated)\n", table.name, total-copied, total, orphaned, total-copied-orphaned)
+	}
+	return nil
+}
+
+// swapTables 元のテーブルをoldNameに、newNameのテーブルを元の名前に変更する
+// MySQLでは1つの文で入れ替え、元の名前のテーブルが存在しない状態を作らない
+func swapTables(tx *gorm.DB, name string, newName string, oldName string) error {
+	if IsSQLite() {
+		queries := []string{
+			fmt.Sprintf("ALTER TABLE %s RENAME TO %s", name, oldName),
+			fmt.Sprintf("ALTER TABLE %s RENAME TO %s", newName, name),
+		}
+		for _, query := range queries {
+			if err := tx.Exec(query).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return tx.Exec(fmt.Sprintf("RENAME TABLE %s TO %s, %s TO %s", name, oldName, newName, name)).Error
+}
+
+// createTable 定義通りのテーブルをnameで作成する
+func createTable(tx *gorm.DB, name string, table tableSchema) error {
+	var definitions []string
+	for _, column := range table.columns {
+		definitions = append(definitions, column.name+" "+column.definition)
+	}
+	definitions = append(definitions, table.constraints...)
+	return tx.Exec(fmt.Sprintf("CREATE TABLE %s (%s)", name, strings.Join(definitions, ", "))).Error
+}
+
+// createIndexes 定義されたインデックスのうち、作成されていないものを作成する
+func createIndexes(tx *gorm.DB, table tableSchema) error {
+	for _, index := range table.indexes {
+		if tx.Dialect().HasIndex(table.name, index.name) {
+			continue
+		}
+		create := "CREATE INDEX"
+		if index.unique {
+			create = "CREATE UNIQUE INDEX"
+		}
+		if err := tx.Exec(fmt.Sprintf("%s %s ON %s (%s)", create, index.name, table.name, index.columns)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// autoIncrement 主キーを自動採番にする指定を返す
+func autoIncrement() string {
+	if IsSQLite() {
+		return " AUTOINCREMENT"
+	}
+	return " AUTO_INCREMENT"
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+)
+
+// txKey contextにトランザクションを紐付けるキー
+type txKey struct{}
+
+// Transaction fnを1つのトランザクションで実行する
+// fnに渡すcontextにトランザクションを紐付け、Connで取り出せるようにする
+// fnがエラーを返した場合、panicした場合はロールバックする
+// 既にトランザクションが紐付いたcontextの場合は、そのトランザクションに参加する
+func Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	tx := GetDB().BeginTx(ctx, nil)
+	if err := tx.Error; err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// Conn contextに紐付くトランザクションを返す。紐付いていない場合はDB接続を返す
+// いずれもctxがキャンセルされた時点で実行中のクエリを中断する
+func Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return withContext(ctx)
+}
@@ -9,9 +9,11 @@ type Comment struct {
 	CommentID      uint32 `gorm:"primary_key"`
 	PostID         uint32 `validate:"required,number"`
 	CreateUserID   uint32 `validate:"required,number"`
-	CommentContent string `validate:"min=1,max=120"`
+	CommentContent string `validate:"min=1,max=120" proto:"content"`
+	Version        uint32 `gorm:"default:1"`
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	DeletedAt      *time.Time `sql:"index"`
 }
 
 // JoinComment コメント紐付け構造体
@@ -0,0 +1,13 @@
+package model
+
+// IntegrityIssue 参照先が存在しない紐付け情報の検査結果
+type IntegrityIssue struct {
+	// 紐付け情報のテーブル名
+	Table string
+	// 存在しない参照先のテーブル名
+	Reference string
+	// 参照先が存在しない行数
+	Count int64
+	// 削除して修復したか
+	Repaired bool
+}
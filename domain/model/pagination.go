@@ -0,0 +1,9 @@
+package model
+
+// Pagination 一覧取得のページング条件
+type Pagination struct {
+	// 1ページあたりの取得件数
+	PageSize uint32
+	// 前ページの末尾を示すトークン
+	PageToken string
+}
@@ -10,13 +10,16 @@ var validate *validator.Validate
 
 // Post 投稿サービス構造体
 type Post struct {
-	ID uint32 `gorm:"primary_key"`
-	// Status       uint32 `validate:"required,number"`
+	ID           uint32 `gorm:"primary_key"`
+	Status       uint32 `gorm:"default:2;index:idx_posts_status_publish_at" validate:"number"`
 	Title        string `validate:"min=1,max=32"`
 	Content      string `validate:"min=1,max=240"`
 	Image        string
-	CreateUserID uint32 `validate:"required,number"`
-	UpdateUserID uint32 `validate:"number"`
+	CreateUserID uint32     `validate:"required,number"`
+	UpdateUserID uint32     `validate:"number"`
+	Version      uint32     `gorm:"default:1"`
+	PublishAt    *time.Time `gorm:"index:idx_posts_status_publish_at"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	DeletedAt    *time.Time `sql:"index"`
 }
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// TagMatch 複数タグ指定時の条件
+type TagMatch uint32
+
+const (
+	// TagMatchAny いずれかのタグが付けられた投稿
+	TagMatchAny TagMatch = iota
+	// TagMatchAll 全てのタグが付けられた投稿
+	TagMatchAll
+)
+
+// ImageFilter 画像の有無による絞り込み
+type ImageFilter uint32
+
+const (
+	// ImageAny 指定なし
+	ImageAny ImageFilter = iota
+	// ImageWith 画像ありの投稿
+	ImageWith
+	// ImageWithout 画像なしの投稿
+	ImageWithout
+)
+
+// PostFilter 投稿一覧の絞り込み条件
+// 指定した全ての条件を満たす投稿に絞り込む
+type PostFilter struct {
+	// 指定したいずれかのユーザーが作成した投稿
+	CreateUserIDs []uint32
+	TagIDs        []uint32
+	TagMatch      TagMatch
+	// 指定したユーザーがいいねした投稿(0の場合は指定なし)
+	LikedByUserID uint32
+	// 作成日時がこの日時以降の投稿
+	CreatedAfter *time.Time
+	// 作成日時がこの日時より前の投稿
+	CreatedBefore *time.Time
+	Image         ImageFilter
+	// コメント数がこの件数以上の投稿(0の場合は指定なし)
+	MinCommentCount uint32
+	// コメント数がこの件数以下の投稿(nilの場合は上限なし)
+	MaxCommentCount *uint32
+}
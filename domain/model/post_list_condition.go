@@ -0,0 +1,33 @@
+package model
+
+// PostSort 投稿一覧の並び順
+type PostSort uint32
+
+const (
+	// PostSortNewest 作成日時の新しい順
+	PostSortNewest PostSort = iota
+	// PostSortOldest 作成日時の古い順
+	PostSortOldest
+	// PostSortMostLikes いいね数の多い順
+	PostSortMostLikes
+	// PostSortMostComments コメント数の多い順
+	PostSortMostComments
+	// PostSortTrending いいね数とコメント数を経過時間で減衰させたスコアの高い順
+	PostSortTrending
+)
+
+// PostListCondition 投稿一覧の取得条件
+type PostListCondition struct {
+	// 検索条件(create, like, tag)
+	Condition string
+	// 検索条件に対応するユーザーID、タグID
+	ID uint32
+	// 閲覧ユーザーID
+	ViewerID uint32
+	// 投稿ステータス(0の場合は指定なし)
+	Status uint32
+	// 絞り込み条件(Conditionと併用した場合は全ての条件を満たす投稿)
+	Filter PostFilter
+	// 並び順
+	Sort PostSort
+}
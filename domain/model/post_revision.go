@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// PostRevision 投稿の更新履歴
+// 作成、更新の度に更新後の内容を1件追加し、以後変更しない
+type PostRevision struct {
+	ID           uint32 `gorm:"primary_key"`
+	PostID       uint32 `gorm:"unique_index:idx_post_revisions_post_id_revision"`
+	Revision     uint32 `gorm:"unique_index:idx_post_revisions_post_id_revision"`
+	Title        string
+	Content      string
+	UpdateUserID uint32
+	CreatedAt    time.Time
+}
+
+// PostRevisionTag 更新履歴時点で投稿に紐付けられていたタグ
+type PostRevisionTag struct {
+	RevisionID uint32 `gorm:"index"`
+	TagID      uint32
+}
+
+// JoinPostRevision 更新履歴とタグの紐付け構造体
+type JoinPostRevision struct {
+	Revision PostRevision
+	TagIDs   []uint32
+}
+
+// PostRevisionDiff 直前の更新履歴との差分
+type PostRevisionDiff struct {
+	// 直前の版数(最初の版の場合は0)
+	PreviousRevision uint32
+	TitleChanged     bool
+	PreviousTitle    string
+	ContentChanged   bool
+	PreviousContent  string
+	AddedTagIDs      []uint32
+	RemovedTagIDs    []uint32
+}
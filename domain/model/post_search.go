@@ -0,0 +1,20 @@
+package model
+
+// PostSearchQuery 投稿の全文検索条件
+type PostSearchQuery struct {
+	// 検索キーワード(空白区切りで複数指定した場合は全てを含む投稿)
+	Keyword string
+	// 指定した全てのタグが付けられた投稿に絞り込む
+	TagIDs []uint32
+	// 指定したいずれかのユーザーが作成した投稿に絞り込む
+	CreateUserIDs []uint32
+	// 閲覧ユーザーID
+	ViewerID uint32
+}
+
+// SearchHit 全文検索の結果1件
+type SearchHit struct {
+	PostID uint32
+	// 検索キーワードとの関連度(大きいほど上位)
+	Score float64
+}
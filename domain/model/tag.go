@@ -4,10 +4,10 @@ import "time"
 
 // Tag タグサービス構造体
 type Tag struct {
-	ID           uint32 `gorm:"primary_key"`
+	ID           uint32 `gorm:"primary_key" proto:"tag_id"`
 	TagName      string `validate:"min=1,max=12"`
-	CreateUserID uint32 `validate:"required,number"`
-	UpdateUserID uint32 `validate:"number"`
+	CreateUserID uint32 `validate:"required,number" proto:"createUser_id"`
+	UpdateUserID uint32 `validate:"number" proto:"updateUser_id"`
 	Status       uint32 `validate:"number"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
@@ -1,5 +1,16 @@
 package model
 
+const (
+	// AuthorityUser 一般ユーザーの権限。自分の投稿、コメントのみ編集、削除できる
+	AuthorityUser uint32 = 0
+	// AuthorityModerator モデレーターの権限。全ての投稿、コメントを非表示にできる
+	AuthorityModerator uint32 = 1
+	// AuthorityOperator 運営ユーザーの権限。モデレーターと同じ操作ができる
+	AuthorityOperator uint32 = 2
+	// AuthorityAdmin 管理者の権限。モデレーターの操作に加え、タグの管理と退会ユーザーのデータ削除ができる
+	AuthorityAdmin uint32 = 9
+)
+
 // User ユーザー構造体
 type User struct {
 	ID        uint32 `gorm:"primary_key"`
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	validate = validator.New()
+	// 検証エラーの項目名は、クライアントが指定するprotoのフィールド名で返す
+	validate.RegisterTagNameFunc(protoFieldName)
+}
+
+// Validate モデルの構造体をvalidateタグの条件で検証する
+// 検証エラーはvalidator.ValidationErrorsで全ての項目分を返す
+func Validate(s interface{}) error {
+	return validate.Struct(s)
+}
+
+// FieldPath 検証エラーの項目のprotoのフィールドパスを返す(例: post.title)
+func FieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	i := strings.Index(namespace, ".")
+	if i < 0 {
+		return namespace
+	}
+	// 先頭の構造体名は、リクエストのフィールド名にする
+	return toSnakeCase(namespace[:i]) + namespace[i:]
+}
+
+// protoFieldName 構造体のフィールドに対応するprotoのフィールド名を返す
+// protoタグがない場合は、フィールド名をスネークケースにした名前とする
+func protoFieldName(field reflect.StructField) string {
+	if name := field.Tag.Get("proto"); name != "" {
+		return name
+	}
+	return toSnakeCase(field.Name)
+}
+
+// toSnakeCase フィールド名をスネークケースにする(例: CreateUserID -> create_user_id)
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
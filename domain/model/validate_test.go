@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestValidateFieldPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  []string
+	}{
+		{"post", &Post{CreateUserID: 1}, []string{"post.title:min=1", "post.content:min=1"}},
+		{"comment", &Comment{CommentContent: "comment"}, []string{"comment.post_id:required", "comment.create_user_id:required"}},
+		{"tag", &Tag{TagName: "aaaaaaaaaaaaa"}, []string{"tag.tag_name:max=12", "tag.createUser_id:required"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.value)
+			validationErrors, ok := err.(validator.ValidationErrors)
+			if !ok {
+				t.Fatalf("Validate() error = %v, want validator.ValidationErrors", err)
+			}
+			var got []string
+			for _, fieldErr := range validationErrors {
+				rule := fieldErr.Tag()
+				if fieldErr.Param() != "" {
+					rule += "=" + fieldErr.Param()
+				}
+				got = append(got, FieldPath(fieldErr)+":"+rule)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("violations = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("violations[%d] = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Post":           "post",
+		"PostRevision":   "post_revision",
+		"CreateUserID":   "create_user_id",
+		"ID":             "id",
+		"CommentContent": "comment_content",
+	}
+	for name, want := range tests {
+		if got := toSnakeCase(name); got != want {
+			t.Errorf("toSnakeCase(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
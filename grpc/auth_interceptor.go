@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yzmw1213/PostService/auth"
+	"github.com/yzmw1213/PostService/domain/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// StatusUnauthenticated トークンがない、または不正な時のエラーステータス
+	StatusUnauthenticated string = "UNAUTHENTICATED_ERROR"
+	// authorizationMetadataKey トークンを指定するメタデータのキー
+	authorizationMetadataKey = "authorization"
+	// bearerPrefix authorizationメタデータのトークンの接頭辞
+	bearerPrefix = "bearer "
+)
+
+// anonymousMethods トークンなしで呼び出せるメソッド
+// トークンが指定された場合は検証し、閲覧したユーザーとして扱う
+var anonymousMethods = map[string]bool{
+	"/postservice.PostService/ListPost":    true,
+	"/postservice.PostService/ReadPost":    true,
+	"/postservice.PostService/SearchPosts": true,
+	"/tagservice.TagService/ListTag":       true,
+	"/tagservice.TagService/ListValidTag":  true,
+	"/grpc.health.v1.Health/Check":         true,
+}
+
+// authInterceptor authorizationメタデータのトークンを検証し、認証したユーザーをコンテキストに設定する
+func authInterceptor(verifier *auth.Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token, err := bearerToken(ctx)
+		if err == auth.ErrMissingToken && anonymousMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, StatusUnauthenticated)
+		}
+		principal, err := verifier.Verify(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, StatusUnauthenticated)
+		}
+		return handler(auth.NewContext(ctx, principal), req)
+	}
+}
+
+// bearerToken authorizationメタデータの「Bearer <token>」からトークンを取り出す
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", auth.ErrMissingToken
+	}
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return "", auth.ErrMissingToken
+	}
+	if len(values[0]) <= len(bearerPrefix) || !strings.EqualFold(values[0][:len(bearerPrefix)], bearerPrefix) {
+		return "", auth.ErrInvalidToken
+	}
+	return strings.TrimSpace(values[0][len(bearerPrefix):]), nil
+}
+
+// actingUser リクエストを実行するユーザーを、権限の判定に使うユーザー情報として返す
+func actingUser(ctx context.Context) model.User {
+	principal, _ := auth.FromContext(ctx)
+	return model.User{ID: principal.UserID, Authority: principal.Authority}
+}
+
+// actingUserID リクエストを実行するユーザーのIDを返す
+// トークンなしで呼び出せるメソッドで、トークンが指定されない場合は0を返す
+func actingUserID(ctx context.Context) uint32 {
+	principal, _ := auth.FromContext(ctx)
+	return principal.UserID
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/auth"
+	"github.com/yzmw1213/PostService/grpc/postservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TestAuthInterceptorUnauthenticated トークンがない、不正な場合にUnauthenticatedを返す事をテスト
+func TestAuthInterceptorUnauthenticated(t *testing.T) {
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := postservice.NewPostServiceClient(conn)
+	req := &postservice.CreatePostRequest{Post: &postservice.Post{Title: "Title", Content: "Content", CreateUserId: 1}}
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{"トークンなし", ""},
+		{"Bearerでない", "Basic dXNlcjpwYXNz"},
+		{"署名が不正", "Bearer eyJhbGciOiJIUzI1NiJ9.eyJ1c2VyX2lkIjoxfQ.invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, authorizationMetadataKey, tt.authorization)
+			}
+			_, err := client.CreatePost(ctx, req)
+			st := status.Convert(err)
+			assert.Equal(t, codes.Unauthenticated, st.Code())
+			assert.Equal(t, StatusUnauthenticated, st.Message())
+			assert.Equal(t, errorMessages[defaultLocale][StatusUnauthenticated], getLocalizedMessage(err).GetMessage())
+		})
+	}
+
+	// 閲覧はトークンなしでもできる
+	_, err = client.ListPost(context.Background(), &postservice.ListPostRequest{})
+	assert.Equal(t, nil, err)
+	// 閲覧でも、不正なトークンは受け付けない
+	ctx := metadata.AppendToOutgoingContext(context.Background(), authorizationMetadataKey, "Bearer invalid")
+	_, err = client.ListPost(ctx, &postservice.ListPostRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// TestCreatePostActingUser リクエストの作成ユーザーではなく、認証したユーザーで投稿を作成する事をテスト
+func TestCreatePostActingUser(t *testing.T) {
+	var actingUserID uint32 = 444444
+	ctx := withUser(t, actingUserID)
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := postservice.NewPostServiceClient(conn)
+
+	_, err = client.CreatePost(ctx, &postservice.CreatePostRequest{Post: &postservice.Post{Title: "Title", Content: "Content", CreateUserId: 1}})
+	assert.Equal(t, nil, err)
+	listRes, err := client.ListPost(ctx, &postservice.ListPostRequest{Condition: "create", Id: actingUserID})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(listRes.GetPost()))
+	assert.Equal(t, actingUserID, listRes.GetPost()[0].GetCreateUserId())
+}
+
+// TestBearerToken authorizationメタデータからトークンを取り出す事をテスト
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		md    metadata.MD
+		token string
+		err   error
+	}{
+		{"メタデータなし", nil, "", auth.ErrMissingToken},
+		{"トークンなし", metadata.Pairs("accept-language", "ja"), "", auth.ErrMissingToken},
+		{"Bearer", metadata.Pairs(authorizationMetadataKey, "Bearer token"), "token", nil},
+		{"小文字", metadata.Pairs(authorizationMetadataKey, "bearer token"), "token", nil},
+		{"Bearer以外", metadata.Pairs(authorizationMetadataKey, "Basic token"), "", auth.ErrInvalidToken},
+		{"接頭辞のみ", metadata.Pairs(authorizationMetadataKey, "Bearer "), "", auth.ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			token, err := bearerToken(ctx)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.token, token)
+		})
+	}
+}
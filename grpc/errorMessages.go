@@ -1,6 +1,132 @@
 package grpc
 
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
 const (
-	messageContentMax = "内容は32文字以内で入力してください"
-	messageContentMin = "内容は1文字以上で入力してください"
+	// localeJa 日本語のロケール
+	localeJa = "ja"
+	// localeEn 英語のロケール
+	localeEn = "en"
+	// defaultLocale メタデータで対応するロケールが指定されない場合のロケール
+	defaultLocale = localeJa
+	// localeMetadataKey ロケールを指定するメタデータのキー(HTTPのAccept-Languageと同じ形式)
+	localeMetadataKey = "accept-language"
 )
+
+// errorMessages ロケールごとの、エラーステータスに対応するメッセージ
+// StatusCommentContentStringCountはStatusPostContentStringCountと同じ値のため、共通のメッセージとする
+var errorMessages = map[string]map[string]string{
+	localeJa: {
+		StatusNotFound:               "指定したデータは存在しません",
+		StatusPostNotExists:          "指定した投稿は存在しません",
+		StatusCommentNotExists:       "指定したコメントは存在しません",
+		StatusTagNotExists:           "指定したタグは存在しません",
+		StatusPostRevisionNotExists:  "指定した投稿の更新履歴は存在しません",
+		StatusAlreadyExists:          "既に登録されています",
+		StatusAlreadyLiked:           "既にお気に入りに登録しています",
+		StatustagNameAlreadyUsed:     "このタグ名は既に使われています",
+		StatusUnauthenticated:        "ログインしてください",
+		StatusPermissionDenied:       "この操作を行う権限がありません",
+		StatusVersionConflict:        "他の更新と競合しました。最新の内容を取得してやり直してください",
+		StatusInvalidArgument:        "指定した条件が正しくありません",
+		StatusPostTitleStringCount:   "件名は1文字以上32文字以内で入力してください",
+		StatusPostContentStringCount: "内容の文字数が正しくありません(投稿は240文字以内、コメントは120文字以内)",
+		StatusTagNameStringCount:     "タグ名は1文字以上12文字以内で入力してください",
+		StatusUnavailable:            "現在サービスを利用できません。しばらくしてからやり直してください",
+		StatusInternal:               "エラーが発生しました",
+	},
+	localeEn: {
+		StatusNotFound:               "The requested data does not exist.",
+		StatusPostNotExists:          "The post does not exist.",
+		StatusCommentNotExists:       "The comment does not exist.",
+		StatusTagNotExists:           "The tag does not exist.",
+		StatusPostRevisionNotExists:  "The post has no revision history.",
+		StatusAlreadyExists:          "It is already registered.",
+		StatusAlreadyLiked:           "You have already liked this post.",
+		StatustagNameAlreadyUsed:     "This tag name is already in use.",
+		StatusUnauthenticated:        "Please sign in.",
+		StatusPermissionDenied:       "You do not have permission to perform this operation.",
+		StatusVersionConflict:        "It was updated by someone else. Reload the latest version and try again.",
+		StatusInvalidArgument:        "The request contains invalid conditions.",
+		StatusPostTitleStringCount:   "The title must be between 1 and 32 characters.",
+		StatusPostContentStringCount: "The content length is invalid (up to 240 characters for posts, 120 for comments).",
+		StatusTagNameStringCount:     "The tag name must be between 1 and 12 characters.",
+		StatusUnavailable:            "The service is temporarily unavailable. Please try again later.",
+		StatusInternal:               "An error occurred.",
+	},
+}
+
+// localize エラーステータスに対応する、リクエストのロケールのメッセージを詳細に付けて返す
+// カタログにないステータスの場合はそのまま返す
+func localize(ctx context.Context, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	locale := localeFromContext(ctx)
+	message, ok := errorMessages[locale][st.Message()]
+	if !ok {
+		return err
+	}
+	return withDetails(st, &errdetails.LocalizedMessage{Locale: locale, Message: message})
+}
+
+// localeFromContext メタデータのAccept-Languageから、対応するロケールのうち最も優先度の高いものを返す
+func localeFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return defaultLocale
+	}
+	return selectLocale(md.Get(localeMetadataKey))
+}
+
+// selectLocale Accept-Languageの値(例: "en-US,en;q=0.9,ja;q=0.8")から対応するロケールを選ぶ
+// q値が同じ場合は先に指定されたものを優先する
+func selectLocale(values []string) string {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+	var candidates []candidate
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			parts := strings.Split(entry, ";")
+			tag := strings.ToLower(strings.TrimSpace(parts[0]))
+			if i := strings.Index(tag, "-"); i >= 0 {
+				tag = tag[:i]
+			}
+			if _, ok := errorMessages[tag]; !ok {
+				continue
+			}
+			q := 1.0
+			for _, param := range parts[1:] {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					if parsed, err := strconv.ParseFloat(param[2:], 64); err == nil {
+						q = parsed
+					}
+				}
+			}
+			if q <= 0 {
+				continue
+			}
+			candidates = append(candidates, candidate{locale: tag, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return defaultLocale
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale
+}
@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/grpc/postservice"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TestSelectLocale Accept-Languageから対応するロケールが選ばれる事をテスト
+func TestSelectLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{"指定なし", nil, localeJa},
+		{"英語", []string{"en"}, localeEn},
+		{"地域付き", []string{"en-US"}, localeEn},
+		{"大文字", []string{"EN-gb"}, localeEn},
+		{"q値で優先", []string{"ja;q=0.5, en;q=0.8"}, localeEn},
+		{"同じq値は先を優先", []string{"en, ja"}, localeEn},
+		{"未対応のロケールは無視", []string{"fr-FR, en;q=0.1"}, localeEn},
+		{"対応するロケールなし", []string{"fr, de"}, localeJa},
+		{"q=0は除外", []string{"en;q=0, ja;q=0.1"}, localeJa},
+		{"複数の値", []string{"fr", "en"}, localeEn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectLocale(tt.values))
+		})
+	}
+}
+
+// TestErrorMessagesCatalog 全てのロケールで同じエラーステータスのメッセージがある事をテスト
+func TestErrorMessagesCatalog(t *testing.T) {
+	for locale, messages := range errorMessages {
+		for code := range errorMessages[defaultLocale] {
+			if messages[code] == "" {
+				t.Errorf("locale %s has no message for %s", locale, code)
+			}
+		}
+	}
+}
+
+// TestLocalize エラーの詳細に、既存の詳細を残してLocalizedMessageが付く事をテスト
+func TestLocalize(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(localeMetadataKey, "en-US,en;q=0.9"))
+	err := withDetails(status.New(codes.InvalidArgument, StatusPostTitleStringCount), &errdetails.BadRequest{})
+
+	st := status.Convert(localize(ctx, err))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, StatusPostTitleStringCount, st.Message())
+	assert.Equal(t, 2, len(st.Details()))
+	message := getLocalizedMessage(localize(ctx, err))
+	assert.Equal(t, localeEn, message.GetLocale())
+	assert.Equal(t, errorMessages[localeEn][StatusPostTitleStringCount], message.GetMessage())
+
+	// カタログにないステータスはそのまま返す
+	canceled := status.Error(codes.Canceled, "context canceled")
+	assert.Equal(t, canceled, localize(ctx, canceled))
+}
+
+// TestCreatePostLocalizedMessage メタデータで指定したロケールのメッセージが返る事をテスト
+func TestCreatePostLocalizedMessage(t *testing.T) {
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := postservice.NewPostServiceClient(conn)
+	req := &postservice.CreatePostRequest{
+		Post: &postservice.Post{Title: "", Content: "Content", CreateUserId: 555555},
+	}
+
+	// 指定なしの場合は日本語
+	ctx := withUser(t, 555555)
+	_, err = client.CreatePost(ctx, req)
+	message := getLocalizedMessage(err)
+	assert.Equal(t, localeJa, message.GetLocale())
+	assert.Equal(t, errorMessages[localeJa][StatusPostTitleStringCount], message.GetMessage())
+
+	ctx = metadata.AppendToOutgoingContext(ctx, localeMetadataKey, "en")
+	_, err = client.CreatePost(ctx, req)
+	message = getLocalizedMessage(err)
+	assert.Equal(t, localeEn, message.GetLocale())
+	assert.Equal(t, errorMessages[localeEn][StatusPostTitleStringCount], message.GetMessage())
+	// 検証エラーの詳細も残る
+	assert.Equal(t, "post.title", getFieldViolations(err)[0].GetField())
+}
+
+// getLocalizedMessage エラーの詳細に含まれるLocalizedMessageを返す
+func getLocalizedMessage(err error) *errdetails.LocalizedMessage {
+	for _, detail := range status.Convert(err).Details() {
+		if message, ok := detail.(*errdetails.LocalizedMessage); ok {
+			return message
+		}
+	}
+	return nil
+}
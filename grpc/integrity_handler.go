@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/yzmw1213/PostService/grpc/postservice"
+	"github.com/yzmw1213/PostService/usecase/policy"
+)
+
+const (
+	// StatusCheckIntegritySuccess 整合性検査成功ステータス
+	StatusCheckIntegritySuccess string = "CHECK_INTEGRITY_SUCCESS"
+)
+
+// CheckIntegrity 参照先が存在しない紐付け情報を検査し、指定された場合は削除する
+// 管理者向けのメソッド
+func (s server) CheckIntegrity(ctx context.Context, req *postservice.CheckIntegrityRequest) (*postservice.CheckIntegrityResponse, error) {
+	var issues []*postservice.IntegrityIssue
+	if err := policy.CanCheckIntegrity(actingUser(ctx)); err != nil {
+		return nil, err
+	}
+	rows, err := s.PostUsecase.CheckIntegrity(ctx, req.GetRepair())
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		issues = append(issues, &postservice.IntegrityIssue{
+			Table:     row.Table,
+			Reference: row.Reference,
+			Count:     row.Count,
+			Repaired:  row.Repaired,
+		})
+	}
+	res := &postservice.CheckIntegrityResponse{
+		Status: &postservice.ResponseStatus{
+			Code: StatusCheckIntegritySuccess,
+		},
+		Issues: issues,
+	}
+	return res, nil
+}
@@ -2,16 +2,51 @@ package grpc
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"log"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/interactor"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// StatusNotFound 指定したリソースが存在しない時のエラーステータス
+	StatusNotFound string = "NOT_FOUND_ERROR"
+	// StatusCommentNotExists 指定したコメントの登録がない時のエラーステータス
+	StatusCommentNotExists string = "COMMENT_NOT_EXISTS_ERROR"
+	// StatusPostRevisionNotExists 指定した投稿の更新履歴がない時のエラーステータス
+	StatusPostRevisionNotExists string = "POST_REVISION_NOT_EXISTS_ERROR"
+	// StatusAlreadyExists 登録済みのリソースを登録しようとした時のエラーステータス
+	StatusAlreadyExists string = "ALREADY_EXISTS_ERROR"
+	// StatusPermissionDenied リソースを操作する権限がない時のエラーステータス
+	StatusPermissionDenied string = "PERMISSION_DENIED_ERROR"
+	// StatusInvalidArgument 指定した条件が不正な時のエラーステータス
+	StatusInvalidArgument string = "INVALID_ARGUMENT_ERROR"
+	// StatusUnavailable DB、ストレージなどに接続できない時のエラーステータス
+	StatusUnavailable string = "UNAVAILABLE_ERROR"
+	// StatusInternal 想定外のエラーステータス
+	StatusInternal string = "INTERNAL_ERROR"
+)
+
+// notFoundStatuses リソース種別ごとの、リソースが存在しない時のエラーステータス
+var notFoundStatuses = map[string]string{
+	interactor.ResourcePost:         StatusPostNotExists,
+	interactor.ResourceComment:      StatusCommentNotExists,
+	interactor.ResourceTag:          StatusTagNotExists,
+	interactor.ResourcePostRevision: StatusPostRevisionNotExists,
+}
+
 func transmitStatusInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	// メソッドより前に呼ばれる処理
 
@@ -21,7 +56,14 @@ func transmitStatusInterceptor(ctx context.Context, req interface{}, info *grpc.
 	// メソッドの処理後に呼ばれる処理
 	if err != nil {
 		// ステータス付きのエラーに変換。
-		err = convertErrorWithStatus(err)
+		converted := convertErrorWithStatus(err)
+		// 内部のエラー内容はクライアントに返さず、ログに出力する
+		switch status.Code(converted) {
+		case codes.Internal, codes.Unavailable:
+			log.Printf("%s failed: %v\n", info.FullMethod, err)
+		}
+		// リクエストのロケールのメッセージを付ける
+		err = localize(ctx, converted)
 	}
 
 	// レスポンスを返す
@@ -29,76 +71,152 @@ func transmitStatusInterceptor(ctx context.Context, req interface{}, info *grpc.
 }
 
 func convertErrorWithStatus(err error) error {
-	var errorStatus string
-	var fieldName string
-	var typ string
+	// ステータス付きのエラーはそのまま返す
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	// リクエストがキャンセル、タイムアウトした場合
+	switch errors.Cause(err) {
+	case context.Canceled:
+		return status.FromContextError(context.Canceled).Err()
+	case context.DeadlineExceeded:
+		return status.FromContextError(context.DeadlineExceeded).Err()
+	}
 
 	// validation エラーの場合
-	if _, ok := errors.Cause(err).(validator.ValidationErrors); ok {
-		for _, err := range err.(validator.ValidationErrors) {
-			fieldName = err.Field()
-			switch fieldName {
-			// 投稿Contentのバリデーションエラー
-			case "Content":
-				typ = err.Tag()
-				switch typ {
-				case "max":
-					errorStatus = StatusPostContentStringCount
-					break
-				case "min":
-					errorStatus = StatusPostContentStringCount
-					break
-				}
-			// 投稿Titleのバリデーションエラー
-			case "Title":
-				typ = err.Tag()
-				switch typ {
-				case "max":
-					errorStatus = StatusPostTitleStringCount
-					break
-				case "min":
-					errorStatus = StatusPostTitleStringCount
-					break
-				}
-			// タグ名のバリデーションエラー
-			case "TagName":
-				typ = err.Tag()
-				switch typ {
-				case "min":
-					errorStatus = StatusTagNameStringCount
-					break
-				case "max":
-					errorStatus = StatusTagNameStringCount
-					break
-				}
-			// コメントContentのバリデーションエラー
-			case "CommentContent":
-				typ = err.Tag()
-				switch typ {
-				case "min":
-					errorStatus = StatusCommentContentStringCount
-					break
-				case "max":
-					errorStatus = StatusCommentContentStringCount
-					break
-				}
-			}
-		}
-	} else {
-		errorStatus = err.Error()
+	if validationErrors, ok := errors.Cause(err).(validator.ValidationErrors); ok {
+		return convertValidationErrors(validationErrors)
 	}
 
-	st := status.New(codes.InvalidArgument, errorStatus)
+	return convertDomainError(err)
+}
 
-	v := &errdetails.BadRequest{
-		FieldViolations: []*errdetails.BadRequest_FieldViolation{
-			{
-				Field:       fieldName,
-				Description: errorStatus,
+// convertDomainError ドメインエラーの種類に応じたコード、詳細を持つエラーに変換する
+// 想定外のエラーの内容はクライアントに返さない
+func convertDomainError(err error) error {
+	var domainErr *interactor.Error
+	if !stderrors.As(err, &domainErr) {
+		domainErr = &interactor.Error{Err: err}
+	}
+
+	switch interactor.KindOf(err) {
+	case interactor.KindNotFound:
+		message, ok := notFoundStatuses[domainErr.Resource]
+		if !ok {
+			message = StatusNotFound
+		}
+		return withDetails(status.New(codes.NotFound, message), resourceInfo(domainErr, message))
+	case interactor.KindAlreadyExists:
+		message := StatusAlreadyExists
+		if stderrors.Is(err, interactor.ErrAlreadyLiked) {
+			message = StatusAlreadyLiked
+		}
+		return withDetails(status.New(codes.AlreadyExists, message), resourceInfo(domainErr, message))
+	case interactor.KindPermissionDenied:
+		return withDetails(status.New(codes.PermissionDenied, StatusPermissionDenied), resourceInfo(domainErr, StatusPermissionDenied))
+	case interactor.KindConflict:
+		// 楽観的排他制御で更新が競合した場合は、最新の版数を取得し直して再試行する
+		return withDetails(status.New(codes.Aborted, StatusVersionConflict), &errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{
+				{
+					Type:        "VERSION",
+					Subject:     resourceName(domainErr),
+					Description: StatusVersionConflict,
+				},
+			},
+		})
+	case interactor.KindUnavailable:
+		delay := domainErr.RetryDelay
+		if delay <= 0 {
+			delay = time.Second
+		}
+		return withDetails(status.New(codes.Unavailable, StatusUnavailable), &errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(delay)})
+	case interactor.KindInvalidArgument:
+		// 不正な条件のエラーは定義済みのメッセージのため、そのまま返す
+		return withDetails(status.New(codes.InvalidArgument, StatusInvalidArgument), &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Description: invalidArgumentDescription(err),
+				},
 			},
-		},
+		})
+	}
+	return status.New(codes.Internal, StatusInternal).Err()
+}
+
+// invalidArgumentDescription 不正な条件のエラーの定義済みのメッセージを返す
+func invalidArgumentDescription(err error) string {
+	for {
+		next := stderrors.Unwrap(err)
+		if next == nil {
+			return err.Error()
+		}
+		err = next
 	}
-	dt, _ := st.WithDetails(v)
+}
+
+// resourceInfo 対象のリソースを示す詳細を返す
+func resourceInfo(domainErr *interactor.Error, description string) *errdetails.ResourceInfo {
+	return &errdetails.ResourceInfo{
+		ResourceType: domainErr.Resource,
+		ResourceName: resourceName(domainErr),
+		Description:  description,
+	}
+}
 
+// resourceName リソース種別とIDからリソース名を返す
+func resourceName(domainErr *interactor.Error) string {
+	if domainErr.ID == 0 {
+		return domainErr.Resource
+	}
+	return fmt.Sprintf("%s/%d", domainErr.Resource, domainErr.ID)
+}
+
+// withDetails ステータスに詳細を付けたエラーを返す
+func withDetails(st *status.Status, details ...proto.Message) error {
+	dt, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
 	return dt.Err()
 }
+
+// validationStatuses 先頭の検証エラーの項目ごとの、InvalidArgumentのエラーステータス
+// 一覧にない項目の場合はStatusInvalidArgumentを返す
+var validationStatuses = map[string]string{
+	"post.title":      StatusPostTitleStringCount,
+	"post.content":    StatusPostContentStringCount,
+	"comment.content": StatusCommentContentStringCount,
+	"tag.tag_name":    StatusTagNameStringCount,
+}
+
+// convertValidationErrors バリデーションエラーをInvalidArgumentに変換する
+// 検証エラー1件ごとに、protoのフィールドパスと検証条件を持つFieldViolationを返す
+func convertValidationErrors(validationErrors validator.ValidationErrors) error {
+	if len(validationErrors) == 0 {
+		return status.Error(codes.InvalidArgument, StatusInvalidArgument)
+	}
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       model.FieldPath(fieldErr),
+			Description: validationRule(fieldErr),
+		})
+	}
+
+	errorStatus, ok := validationStatuses[violations[0].Field]
+	if !ok {
+		errorStatus = StatusInvalidArgument
+	}
+	st := status.New(codes.InvalidArgument, errorStatus)
+	return withDetails(st, &errdetails.BadRequest{FieldViolations: violations})
+}
+
+// validationRule 検証エラーの条件を、パラメータがある場合は"max=32"の形式で返す
+func validationRule(fieldErr validator.FieldError) string {
+	if fieldErr.Param() == "" {
+		return fieldErr.Tag()
+	}
+	return fieldErr.Tag() + "=" + fieldErr.Param()
+}
@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/usecase/interactor/memory"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestConvertVersionConflict 更新競合がAbortedに変換される事をテスト
+func TestConvertVersionConflict(t *testing.T) {
+	err := convertErrorWithStatus(errors.Wrap(interactor.ErrVersionConflict, "update post"))
+
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.Aborted, st.Code())
+	assert.Equal(t, StatusVersionConflict, st.Message())
+}
+
+// TestConvertAlreadyLiked 重複したお気に入りがAlreadyExistsに変換される事をテスト
+func TestConvertAlreadyLiked(t *testing.T) {
+	err := convertErrorWithStatus(interactor.ErrAlreadyLiked)
+
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	assert.Equal(t, StatusAlreadyLiked, st.Message())
+}
+
+// TestConvertInvalidListCondition 不正な一覧取得条件がInvalidArgumentに変換される事をテスト
+func TestConvertInvalidListCondition(t *testing.T) {
+	err := convertErrorWithStatus(interactor.ErrInvalidListCondition)
+
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+// TestConvertContextError キャンセル、タイムアウトがそれぞれのコードに変換される事をテスト
+func TestConvertContextError(t *testing.T) {
+	st, _ := status.FromError(convertErrorWithStatus(context.Canceled))
+	assert.Equal(t, codes.Canceled, st.Code())
+
+	st, _ = status.FromError(convertErrorWithStatus(errors.Wrap(context.DeadlineExceeded, "list posts")))
+	assert.Equal(t, codes.DeadlineExceeded, st.Code())
+}
+
+// TestConvertNotFound 存在しないリソースがNotFoundとResourceInfoに変換される事をテスト
+func TestConvertNotFound(t *testing.T) {
+	tags := memory.NewTagRepository()
+	posts := memory.NewPostRepository(tags)
+	_, err := posts.GetByID(context.Background(), 100)
+
+	st, _ := status.FromError(convertErrorWithStatus(errors.Wrap(err, "read post")))
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, StatusPostNotExists, st.Message())
+	info := st.Details()[0].(*errdetails.ResourceInfo)
+	assert.Equal(t, interactor.ResourcePost, info.GetResourceType())
+	assert.Equal(t, "posts/100", info.GetResourceName())
+
+	// リソースが特定できない場合も、NotFoundに変換される
+	st, _ = status.FromError(convertErrorWithStatus(gorm.ErrRecordNotFound))
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, StatusNotFound, st.Message())
+}
+
+// TestConvertConflictDetails 更新競合にPreconditionFailureが付けられる事をテスト
+func TestConvertConflictDetails(t *testing.T) {
+	tags := memory.NewTagRepository()
+	posts := memory.NewPostRepository(tags)
+	created, err := posts.Create(context.Background(), &model.JoinPost{Post: &model.Post{Title: "Title", Content: "Content", CreateUserID: one}})
+	assert.Equal(t, nil, err)
+	_, err = posts.Update(context.Background(), &model.JoinPost{Post: &model.Post{ID: created.Post.ID, Title: "Title", Content: "Content", CreateUserID: one, Version: 100}})
+
+	st, _ := status.FromError(convertErrorWithStatus(err))
+	assert.Equal(t, codes.Aborted, st.Code())
+	violation := st.Details()[0].(*errdetails.PreconditionFailure).GetViolations()[0]
+	assert.Equal(t, "VERSION", violation.GetType())
+	assert.Equal(t, fmt.Sprintf("posts/%d", created.Post.ID), violation.GetSubject())
+}
+
+// TestConvertUnavailable 依存先に接続できない場合にRetryInfoが付けられ、内部のエラー内容を返さない事をテスト
+func TestConvertUnavailable(t *testing.T) {
+	err := convertErrorWithStatus(interactor.NewUnavailableError(interactor.ResourceStorage, errors.New("dial tcp 10.0.0.1:443: i/o timeout")))
+
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, StatusUnavailable, st.Message())
+	retry := st.Details()[0].(*errdetails.RetryInfo)
+	assert.Equal(t, int64(1), retry.GetRetryDelay().GetSeconds())
+
+	st, _ = status.FromError(convertErrorWithStatus(errors.Wrap(driver.ErrBadConn, "list posts")))
+	assert.Equal(t, codes.Unavailable, st.Code())
+}
+
+// TestConvertInternal 想定外のエラーの内容をクライアントに返さない事をテスト
+func TestConvertInternal(t *testing.T) {
+	err := convertErrorWithStatus(errors.New("Error 1054: Unknown column 'secret' in 'field list'"))
+
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, StatusInternal, st.Message())
+	assert.Equal(t, 0, len(st.Details()))
+}
+
+// TestConvertPermissionDenied 権限がない場合にPermissionDeniedに変換される事をテスト
+func TestConvertPermissionDenied(t *testing.T) {
+	st, _ := status.FromError(convertErrorWithStatus(interactor.NewPermissionDeniedError(interactor.ResourceTag, 3)))
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	assert.Equal(t, "tags/3", st.Details()[0].(*errdetails.ResourceInfo).GetResourceName())
+}
@@ -0,0 +1,283 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/grpc/postservice"
+	"github.com/yzmw1213/PostService/grpc/tagservice"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// policyFixture 権限のテストで操作する投稿、コメント、タグ
+type policyFixture struct {
+	ownerID   uint32
+	postID    uint32
+	commentID uint32
+	tagID     uint32
+	// 投稿、コメントの現在の版数
+	version        uint32
+	commentVersion uint32
+}
+
+var (
+	policyMu     sync.Mutex
+	policyUserID uint32 = 900000
+)
+
+// nextPolicyUserID 他のテストと重ならない投稿者のユーザーIDを返す
+func nextPolicyUserID() uint32 {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	policyUserID++
+	return policyUserID
+}
+
+// newPolicyFixture 新しい投稿者の投稿、コメントと、タグを登録する
+func newPolicyFixture(t *testing.T, posts postservice.PostServiceClient, tags tagservice.TagServiceClient) policyFixture {
+	t.Helper()
+	f := policyFixture{ownerID: nextPolicyUserID()}
+	owner := withUser(t, f.ownerID)
+	_, err := posts.CreatePost(owner, &postservice.CreatePostRequest{Post: &postservice.Post{Title: "Title", Content: "Content", Tags: []uint32{one}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listRes, err := posts.ListPost(owner, &postservice.ListPostRequest{Condition: "create", Id: f.ownerID})
+	if err != nil || len(listRes.GetPost()) != 1 {
+		t.Fatalf("ListPost: %v", err)
+	}
+	f.postID = listRes.GetPost()[0].GetId()
+	if _, err := posts.CreateComment(owner, &postservice.CreateCommentRequest{Comment: &postservice.Comment{PostId: f.postID, Content: "Comment"}}); err != nil {
+		t.Fatal(err)
+	}
+	readRes, err := posts.ReadPost(owner, &postservice.ReadPostRequest{Id: f.postID})
+	if err != nil || len(readRes.GetPost().GetComments()) != 1 {
+		t.Fatalf("ReadPost: %v", err)
+	}
+	f.commentID = readRes.GetPost().GetComments()[0].GetId()
+	f.version = readRes.GetPost().GetVersion()
+	f.commentVersion = readRes.GetPost().GetComments()[0].GetVersion()
+
+	admin := withAuthority(t, 1, model.AuthorityAdmin)
+	tagName := fmt.Sprintf("p%d", f.ownerID)
+	if _, err := tags.CreateTag(admin, &tagservice.CreateTagRequest{Tag: &tagservice.Tag{TagName: tagName, Status: one}}); err != nil {
+		t.Fatal(err)
+	}
+	tagRes, err := tags.ListTag(admin, &tagservice.ListTagRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range tagRes.GetTag() {
+		if tag.GetTagName() == tagName {
+			f.tagID = tag.GetTagId()
+		}
+	}
+	return f
+}
+
+// setPolicyPostStatus 権限のテストの投稿のステータスを変更し、更新後の版数を設定する
+func setPolicyPostStatus(t *testing.T, posts postservice.PostServiceClient, ctx context.Context, f *policyFixture, status uint32) {
+	t.Helper()
+	req := &postservice.UpdatePostRequest{Post: &postservice.Post{Id: f.postID, Title: "Title", Content: "Content", Tags: []uint32{one}, Status: status, Version: f.version}}
+	res, err := posts.UpdatePost(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.version = res.GetVersion()
+}
+
+// TestPolicyMatrix 操作するユーザーの権限ごとに、変更系のメソッドが許可、拒否される事をテスト
+func TestPolicyMatrix(t *testing.T) {
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	posts := postservice.NewPostServiceClient(conn)
+	tags := tagservice.NewTagServiceClient(conn)
+
+	type call func(t *testing.T, ctx context.Context, f policyFixture) error
+	calls := map[string]call{
+		"UpdatePost": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := posts.UpdatePost(ctx, &postservice.UpdatePostRequest{Post: &postservice.Post{Id: f.postID, Title: "Title", Content: "Updated", Version: f.version}})
+			return err
+		},
+		"HidePost": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := posts.UpdatePost(ctx, &postservice.UpdatePostRequest{Post: &postservice.Post{Id: f.postID, Title: "Title", Content: "Content", Status: interactor.HiddenPostStatus, Version: f.version}})
+			return err
+		},
+		"HideDraftPost": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			setPolicyPostStatus(t, posts, withUser(t, f.ownerID), &f, interactor.DraftPostStatus)
+			_, err := posts.UpdatePost(ctx, &postservice.UpdatePostRequest{Post: &postservice.Post{Id: f.postID, Title: "Title", Content: "Content", Status: interactor.HiddenPostStatus, Version: f.version}})
+			return err
+		},
+		"UnhidePost": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			setPolicyPostStatus(t, posts, withAuthority(t, nextPolicyUserID(), model.AuthorityModerator), &f, interactor.HiddenPostStatus)
+			_, err := posts.UpdatePost(ctx, &postservice.UpdatePostRequest{Post: &postservice.Post{Id: f.postID, Title: "Title", Content: "Content", Status: interactor.PublishedPostStatus, Version: f.version}})
+			return err
+		},
+		"ListPostRevisions": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := posts.ListPostRevisions(ctx, &postservice.ListPostRevisionsRequest{PostId: f.postID})
+			return err
+		},
+		"ListDraftPostRevisions": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			setPolicyPostStatus(t, posts, withUser(t, f.ownerID), &f, interactor.DraftPostStatus)
+			_, err := posts.ListPostRevisions(ctx, &postservice.ListPostRevisionsRequest{PostId: f.postID})
+			return err
+		},
+		"GetDraftPostRevision": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			setPolicyPostStatus(t, posts, withUser(t, f.ownerID), &f, interactor.DraftPostStatus)
+			_, err := posts.GetPostRevision(ctx, &postservice.GetPostRevisionRequest{PostId: f.postID, Revision: 1})
+			return err
+		},
+		"GetTrashedPostRevision": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			if _, err := posts.DeletePost(withUser(t, f.ownerID), &postservice.DeletePostRequest{Id: f.postID}); err != nil {
+				t.Fatal(err)
+			}
+			_, err := posts.GetPostRevision(ctx, &postservice.GetPostRevisionRequest{PostId: f.postID, Revision: 1})
+			return err
+		},
+		"DeletePost": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := posts.DeletePost(ctx, &postservice.DeletePostRequest{Id: f.postID})
+			return err
+		},
+		"RevertPostToRevision": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := posts.RevertPostToRevision(ctx, &postservice.RevertPostToRevisionRequest{PostId: f.postID, Revision: 1, Version: f.version})
+			return err
+		},
+		"UpdateComment": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := posts.UpdateComment(ctx, &postservice.UpdateCommentRequest{Comment: &postservice.Comment{Id: f.commentID, Content: "Updated", Version: f.commentVersion}})
+			return err
+		},
+		"DeleteComment": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := posts.DeleteComment(ctx, &postservice.DeleteCommentRequest{Id: f.commentID})
+			return err
+		},
+		"CreateTag": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := tags.CreateTag(ctx, &tagservice.CreateTagRequest{Tag: &tagservice.Tag{TagName: fmt.Sprintf("c%d", f.ownerID), Status: one}})
+			return err
+		},
+		"UpdateTag": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := tags.UpdateTag(ctx, &tagservice.UpdateTagRequest{Tag: &tagservice.Tag{TagId: f.tagID, TagName: fmt.Sprintf("u%d", f.ownerID), CreateUserId: 1}})
+			return err
+		},
+		"DeleteTag": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := tags.DeleteTag(ctx, &tagservice.DeleteTagRequest{TagId: f.tagID})
+			return err
+		},
+		"DeletePostsCommentsByUserID": func(t *testing.T, ctx context.Context, f policyFixture) error {
+			_, err := posts.DeletePostsCommentsByUserID(ctx, &postservice.DeletePostsCommentsByUserIDRequest{CreateUserId: f.ownerID})
+			return err
+		},
+	}
+
+	// actor 投稿者本人か、操作するユーザーの権限
+	type actor struct {
+		name      string
+		owner     bool
+		authority uint32
+	}
+	owner := actor{"owner", true, model.AuthorityUser}
+	other := actor{"other", false, model.AuthorityUser}
+	moderator := actor{"moderator", false, model.AuthorityModerator}
+	admin := actor{"admin", false, model.AuthorityAdmin}
+
+	tests := []struct {
+		method string
+		actor  actor
+		want   codes.Code
+	}{
+		{"UpdatePost", owner, codes.OK},
+		{"UpdatePost", other, codes.PermissionDenied},
+		{"UpdatePost", moderator, codes.PermissionDenied},
+		{"UpdatePost", admin, codes.PermissionDenied},
+		{"HidePost", owner, codes.OK},
+		{"HidePost", other, codes.PermissionDenied},
+		{"HidePost", moderator, codes.OK},
+		{"HidePost", admin, codes.OK},
+		{"HideDraftPost", owner, codes.InvalidArgument},
+		{"HideDraftPost", other, codes.PermissionDenied},
+		{"HideDraftPost", moderator, codes.OK},
+		{"UnhidePost", owner, codes.PermissionDenied},
+		{"UnhidePost", other, codes.PermissionDenied},
+		{"UnhidePost", moderator, codes.OK},
+		{"ListPostRevisions", other, codes.OK},
+		{"ListDraftPostRevisions", owner, codes.OK},
+		{"ListDraftPostRevisions", other, codes.NotFound},
+		{"ListDraftPostRevisions", moderator, codes.OK},
+		{"GetDraftPostRevision", owner, codes.OK},
+		{"GetDraftPostRevision", other, codes.NotFound},
+		{"GetDraftPostRevision", moderator, codes.OK},
+		{"GetTrashedPostRevision", owner, codes.OK},
+		{"GetTrashedPostRevision", other, codes.NotFound},
+		{"GetTrashedPostRevision", moderator, codes.OK},
+		{"DeletePost", owner, codes.OK},
+		{"DeletePost", other, codes.PermissionDenied},
+		{"DeletePost", moderator, codes.PermissionDenied},
+		{"RevertPostToRevision", owner, codes.OK},
+		{"RevertPostToRevision", other, codes.PermissionDenied},
+		{"RevertPostToRevision", moderator, codes.OK},
+		{"UpdateComment", owner, codes.OK},
+		{"UpdateComment", other, codes.PermissionDenied},
+		{"UpdateComment", moderator, codes.PermissionDenied},
+		{"DeleteComment", owner, codes.OK},
+		{"DeleteComment", other, codes.PermissionDenied},
+		{"DeleteComment", moderator, codes.OK},
+		{"CreateTag", owner, codes.PermissionDenied},
+		{"CreateTag", moderator, codes.PermissionDenied},
+		{"CreateTag", admin, codes.OK},
+		{"UpdateTag", owner, codes.PermissionDenied},
+		{"UpdateTag", moderator, codes.PermissionDenied},
+		{"UpdateTag", admin, codes.OK},
+		{"DeleteTag", owner, codes.PermissionDenied},
+		{"DeleteTag", moderator, codes.PermissionDenied},
+		{"DeleteTag", admin, codes.OK},
+		{"DeletePostsCommentsByUserID", owner, codes.PermissionDenied},
+		{"DeletePostsCommentsByUserID", moderator, codes.PermissionDenied},
+		{"DeletePostsCommentsByUserID", admin, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+"/"+tt.actor.name, func(t *testing.T) {
+			f := newPolicyFixture(t, posts, tags)
+			userID := nextPolicyUserID()
+			if tt.actor.owner {
+				userID = f.ownerID
+			}
+			err := calls[tt.method](t, withAuthority(t, userID, tt.actor.authority), f)
+			assert.Equal(t, tt.want, status.Code(err))
+			if tt.want == codes.PermissionDenied {
+				assert.Equal(t, StatusPermissionDenied, status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+// TestHidePostByModerator モデレーターが非表示にした場合、ステータス以外は変更されない事をテスト
+func TestHidePostByModerator(t *testing.T) {
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	posts := postservice.NewPostServiceClient(conn)
+	f := newPolicyFixture(t, posts, tagservice.NewTagServiceClient(conn))
+
+	moderator := withAuthority(t, nextPolicyUserID(), model.AuthorityModerator)
+	_, err = posts.UpdatePost(moderator, &postservice.UpdatePostRequest{Post: &postservice.Post{Id: f.postID, Title: "Changed", Content: "Changed", Status: interactor.HiddenPostStatus, Version: f.version}})
+	assert.Equal(t, nil, err)
+
+	readRes, err := posts.ReadPost(withUser(t, f.ownerID), &postservice.ReadPostRequest{Id: f.postID})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, interactor.HiddenPostStatus, readRes.GetPost().GetStatus())
+	assert.Equal(t, "Title", readRes.GetPost().GetTitle())
+	assert.Equal(t, "Content", readRes.GetPost().GetContent())
+	assert.Equal(t, []uint32{one}, readRes.GetPost().GetTags())
+	assert.Equal(t, f.ownerID, readRes.GetPost().GetCreateUserId())
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"os"
 	"strings"
 
-	"github.com/yzmw1213/PostService/aws"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/yzmw1213/PostService/domain/model"
 	"github.com/yzmw1213/PostService/grpc/postservice"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/usecase/policy"
 )
 
 const (
@@ -31,6 +32,14 @@ const (
 	StatusDeletePostsCommentsByUserIDSuccess string = "COMMENT_DELETEE_BY_USERID_SUCCESS"
 	// StatusDeletePostSuccess 投稿削除成功ステータス
 	StatusDeletePostSuccess string = "POST_DELETE_SUCCESS"
+	// StatusRestorePostSuccess 投稿復元成功ステータス
+	StatusRestorePostSuccess string = "POST_RESTORE_SUCCESS"
+	// StatusPurgePostSuccess 投稿完全削除成功ステータス
+	StatusPurgePostSuccess string = "POST_PURGE_SUCCESS"
+	// StatusVersionConflict 更新対象が他の更新により変更されていた時のエラーステータス
+	StatusVersionConflict string = "VERSION_CONFLICT_ERROR"
+	// StatusAlreadyLiked 既にお気に入りした投稿をお気に入りした時のエラーステータス
+	StatusAlreadyLiked string = "ALREADY_LIKED_ERROR"
 	// StatusPostNotExists 指定した投稿の登録がない時のエラーステータス
 	StatusPostNotExists string = "POST_NOT_EXISTS_ERROR"
 	// StatusPostTitleStringCount 件名文字数が無効のエラーステータス
@@ -41,16 +50,16 @@ const (
 	StatusCommentContentStringCount string = "POST_CONTENT_COUNT_ERROR"
 )
 
-var (
-	bucket = os.Getenv("AWS_S3_BUCKET_NAME")
-	region = os.Getenv("AWS_S3_REGION")
-)
-
 func (s server) CreatePost(ctx context.Context, req *postservice.CreatePostRequest) (*postservice.CreatePostResponse, error) {
 	var location string
 	postData := req.GetPost()
 
-	post := makePostModel(postData)
+	post, err := makePostModel(postData)
+	if err != nil {
+		return nil, err
+	}
+	// 作成ユーザーはリクエストの値ではなく、認証したユーザーとする
+	post.CreateUserID = actingUserID(ctx)
 	tags := makePostTagModel(postData)
 
 	joinPost := &model.JoinPost{
@@ -60,16 +69,19 @@ func (s server) CreatePost(ctx context.Context, req *postservice.CreatePostReque
 
 	if isBase64(post.Image) == true {
 		// 画像をS3にアップロードし、URLを受け取る。
-		location, err = aws.Upload(post.Image[strings.IndexByte(post.Image, ',')+1:])
+		location, err = s.Storage.Upload(ctx, post.Image[strings.IndexByte(post.Image, ',')+1:])
 
 		if err != nil {
-			return nil, err
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, interactor.NewUnavailableError(interactor.ResourceStorage, err)
 		}
 		joinPost.Post.Image = location
 	}
 
 	// post, tagsをJoinしてinteractor.Createに渡す
-	joinPost, err := s.PostUsecase.Create(joinPost)
+	joinPost, err = s.PostUsecase.Create(ctx, joinPost)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +92,15 @@ func (s server) CreatePost(ctx context.Context, req *postservice.CreatePostReque
 func (s server) DeletePost(ctx context.Context, req *postservice.DeletePostRequest) (*postservice.DeletePostResponse, error) {
 	id := req.GetId()
 
-	if err := s.PostUsecase.DeleteByID(id); err != nil {
+	post, err := s.PostUsecase.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.CanEditPost(actingUser(ctx), post); err != nil {
+		return nil, err
+	}
+
+	if err := s.PostUsecase.DeleteByID(ctx, id); err != nil {
 		return nil, err
 	}
 	return s.makeDeletePostResponse(StatusDeletePostSuccess), nil
@@ -88,27 +108,125 @@ func (s server) DeletePost(ctx context.Context, req *postservice.DeletePostReque
 
 func (s server) ListPost(ctx context.Context, req *postservice.ListPostRequest) (*postservice.ListPostResponse, error) {
 	var posts []*postservice.Post
-	condition := req.GetCondition()
-	ID := req.GetId()
+	filter, err := makePostFilterModel(req.GetFilter())
+	if err != nil {
+		return s.makeListPostResponse(posts, ""), err
+	}
+	condition := model.PostListCondition{
+		Condition: req.GetCondition(),
+		ID:        req.GetId(),
+		ViewerID:  actingUserID(ctx),
+		Status:    req.GetStatus(),
+		Filter:    filter,
+		Sort:      model.PostSort(req.GetSort()),
+	}
+	page := model.Pagination{
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	}
+
+	rows, nextPageToken, err := s.PostUsecase.List(ctx, condition, page)
+	if err != nil {
+		return s.makeListPostResponse(posts, ""), err
+	}
+	for _, post := range rows {
+		post := s.makeGrpcPost(&post)
+		posts = append(posts, post)
+	}
+	return s.makeListPostResponse(posts, nextPageToken), nil
+}
+
+// SearchPosts キーワードに一致する投稿を関連度順に返す
+func (s server) SearchPosts(ctx context.Context, req *postservice.SearchPostsRequest) (*postservice.SearchPostsResponse, error) {
+	var posts []*postservice.Post
+	query := model.PostSearchQuery{
+		Keyword:       req.GetKeyword(),
+		TagIDs:        req.GetTagIds(),
+		CreateUserIDs: req.GetCreateUserIds(),
+		ViewerID:      actingUserID(ctx),
+	}
+	page := model.Pagination{
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	}
+
+	rows, nextPageToken, err := s.PostUsecase.Search(ctx, query, page)
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range rows {
+		post := s.makeGrpcPost(&post)
+		posts = append(posts, post)
+	}
+	res := &postservice.SearchPostsResponse{
+		Count:         uint32(len(posts)),
+		Post:          posts,
+		NextPageToken: nextPageToken,
+	}
+	return res, nil
+}
 
-	rows, err := s.PostUsecase.List(condition, ID)
+// RestorePost ゴミ箱に移動した投稿を元に戻す
+func (s server) RestorePost(ctx context.Context, req *postservice.RestorePostRequest) (*postservice.RestorePostResponse, error) {
+	post, err := s.PostUsecase.GetTrashedByID(ctx, req.GetId())
 	if err != nil {
-		return s.makeListPostResponse(posts), err
+		return nil, err
+	}
+	if err := policy.CanEditPost(actingUser(ctx), post); err != nil {
+		return nil, err
+	}
+	if err := s.PostUsecase.Restore(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return s.makeRestorePostResponse(StatusRestorePostSuccess), nil
+}
+
+// ListTrashedPosts ゴミ箱に移動したユーザーの投稿を取得して返す
+func (s server) ListTrashedPosts(ctx context.Context, req *postservice.ListTrashedPostsRequest) (*postservice.ListTrashedPostsResponse, error) {
+	var posts []*postservice.Post
+	page := model.Pagination{
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	}
+
+	rows, nextPageToken, err := s.PostUsecase.ListTrashed(ctx, actingUserID(ctx), page)
+	if err != nil {
+		return nil, err
 	}
 	for _, post := range rows {
-		post := makeGrpcPost(&post)
+		post := s.makeGrpcPost(&post)
 		posts = append(posts, post)
 	}
-	return s.makeListPostResponse(posts), nil
+	res := &postservice.ListTrashedPostsResponse{
+		Count:         uint32(len(posts)),
+		Post:          posts,
+		NextPageToken: nextPageToken,
+	}
+	return res, nil
+}
+
+// PurgePost ゴミ箱に移動した投稿を完全に削除する
+func (s server) PurgePost(ctx context.Context, req *postservice.PurgePostRequest) (*postservice.PurgePostResponse, error) {
+	post, err := s.PostUsecase.GetTrashedByID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.CanEditPost(actingUser(ctx), post); err != nil {
+		return nil, err
+	}
+	if err := s.PostUsecase.Purge(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return s.makePurgePostResponse(StatusPurgePostSuccess), nil
 }
 
 func (s server) ReadPost(ctx context.Context, req *postservice.ReadPostRequest) (*postservice.ReadPostResponse, error) {
 	ID := req.GetId()
-	row, err := s.PostUsecase.GetJoinPostByID(ID)
+	row, err := s.PostUsecase.GetJoinPostByID(ctx, ID, actingUserID(ctx))
 	if err != nil {
 		return nil, err
 	}
-	post := makeGrpcPost(&row)
+	post := s.makeGrpcPost(&row)
 	res := &postservice.ReadPostResponse{
 		Post: post,
 	}
@@ -118,27 +236,62 @@ func (s server) ReadPost(ctx context.Context, req *postservice.ReadPostRequest)
 func (s server) UpdatePost(ctx context.Context, req *postservice.UpdatePostRequest) (*postservice.UpdatePostResponse, error) {
 	postData := req.GetPost()
 
+	post, err := makePostModel(postData)
+	if err != nil {
+		return nil, err
+	}
 	joinPost := &model.JoinPost{
-		Post:     makePostModel(postData),
+		Post:     post,
 		PostTags: makePostTagModel(postData),
 	}
 	// 更新時はimageの更新は行わない
 	joinPost.Post.Image = ""
+	joinPost.Post.UpdateUserID = actingUserID(ctx)
+
+	current, err := s.PostUsecase.GetByID(ctx, joinPost.Post.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 作成ユーザーは更新前の値のまま残す
+	joinPost.Post.CreateUserID = current.CreateUserID
+	actor := actingUser(ctx)
+	status := joinPost.Post.Status
+	if err := policy.CanUnhidePost(actor, current, status); err != nil {
+		return nil, err
+	}
+	if err := policy.CanEditPost(actor, current); err != nil {
+		// 投稿者以外は、モデレーターが非表示にする、非表示を解除する場合のみ更新できる
+		if status == 0 || (status != interactor.HiddenPostStatus && current.Status != interactor.HiddenPostStatus) {
+			return nil, err
+		}
+		if err := policy.CanHidePost(actor, current); err != nil {
+			return nil, err
+		}
+		if joinPost, err = s.makeModeratePost(ctx, current, actor, status, joinPost.Post.Version); err != nil {
+			return nil, err
+		}
+	}
+	if policy.IsModerator(actor) {
+		ctx = interactor.WithModerator(ctx)
+	}
 
-	if _, err := s.PostUsecase.Update(joinPost); err != nil {
+	updatedPost, err := s.PostUsecase.Update(ctx, joinPost)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.makeUpdatePostResponse(StatusUpdatePostSuccess), nil
+	res := s.makeUpdatePostResponse(StatusUpdatePostSuccess)
+	res.Version = updatedPost.Post.Version
+	return res, nil
 }
 
 func (s server) LikePost(ctx context.Context, req *postservice.LikePostRequest) (*postservice.LikePostResponse, error) {
 	postLikeUser := &model.PostLikeUser{
 		PostID: req.GetId(),
-		UserID: req.GetUserId(),
+		UserID: actingUserID(ctx),
 	}
 
-	if _, err := s.PostUsecase.Like(postLikeUser); err != nil {
+	if _, err := s.PostUsecase.Like(ctx, postLikeUser); err != nil {
 		return nil, err
 	}
 	return s.makeLikePostResponse(StatusLikePostSuccess), nil
@@ -147,10 +300,10 @@ func (s server) LikePost(ctx context.Context, req *postservice.LikePostRequest)
 func (s server) NotLikePost(ctx context.Context, req *postservice.NotLikePostRequest) (*postservice.NotLikePostResponse, error) {
 	postLikeUser := &model.PostLikeUser{
 		PostID: req.GetId(),
-		UserID: req.GetUserId(),
+		UserID: actingUserID(ctx),
 	}
 
-	if _, err := s.PostUsecase.NotLike(postLikeUser); err != nil {
+	if _, err := s.PostUsecase.NotLike(ctx, postLikeUser); err != nil {
 		return nil, err
 	}
 	return s.makeNotLikePostResponse(StatusNotLikePostSuccess), nil
@@ -158,7 +311,8 @@ func (s server) NotLikePost(ctx context.Context, req *postservice.NotLikePostReq
 
 func (s server) CreateComment(ctx context.Context, req *postservice.CreateCommentRequest) (*postservice.CreateCommentResponse, error) {
 	comment := makeComment(req.Comment)
-	if _, err := s.PostUsecase.CreateComment(comment); err != nil {
+	comment.CreateUserID = actingUserID(ctx)
+	if _, err := s.PostUsecase.CreateComment(ctx, comment); err != nil {
 		return nil, err
 	}
 	return s.makeCreateCommentResponse(StatusCreateCommentSuccess), nil
@@ -166,16 +320,37 @@ func (s server) CreateComment(ctx context.Context, req *postservice.CreateCommen
 
 func (s server) UpdateComment(ctx context.Context, req *postservice.UpdateCommentRequest) (*postservice.UpdateCommentResponse, error) {
 	comment := makeComment(req.Comment)
-	if _, err := s.PostUsecase.UpdateComment(comment); err != nil {
+	current, err := s.PostUsecase.GetCommentByID(ctx, comment.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.CanEditComment(actingUser(ctx), current); err != nil {
 		return nil, err
 	}
-	return s.makeUpdateCommentResponse(StatusUpdateCommentSuccess), nil
+	// 投稿先、作成ユーザーは更新前の値のまま残す
+	comment.PostID = current.PostID
+	comment.CreateUserID = current.CreateUserID
+	updatedComment, err := s.PostUsecase.UpdateComment(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	res := s.makeUpdateCommentResponse(StatusUpdateCommentSuccess)
+	res.Version = updatedComment.Version
+	return res, nil
 }
 
 func (s server) DeleteComment(ctx context.Context, req *postservice.DeleteCommentRequest) (*postservice.DeleteCommentResponse, error) {
 	id := req.GetId()
 
-	if err := s.PostUsecase.DeleteComment(id); err != nil {
+	comment, err := s.PostUsecase.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.CanDeleteComment(actingUser(ctx), comment); err != nil {
+		return nil, err
+	}
+
+	if err := s.PostUsecase.DeleteComment(ctx, id); err != nil {
 		return nil, err
 	}
 	return s.makeDeleteCommentResponse(StatusDeleteCommentSuccess), nil
@@ -184,13 +359,17 @@ func (s server) DeleteComment(ctx context.Context, req *postservice.DeleteCommen
 func (s server) DeletePostsCommentsByUserID(ctx context.Context, req *postservice.DeletePostsCommentsByUserIDRequest) (*postservice.DeletePostsCommentsByUserIDResponse, error) {
 	createUserID := req.GetCreateUserId()
 
+	if err := policy.CanDeleteUserContents(actingUser(ctx)); err != nil {
+		return nil, err
+	}
+
 	// 退会ユーザーの投稿記事を削除
-	if err := s.PostUsecase.DeletePostsByUserID(createUserID); err != nil {
+	if err := s.PostUsecase.DeletePostsByUserID(ctx, createUserID); err != nil {
 		return nil, err
 	}
 
 	// 退会ユーザーのコメントを削除
-	if err := s.PostUsecase.DeleteCommentsByUserID(createUserID); err != nil {
+	if err := s.PostUsecase.DeleteCommentsByUserID(ctx, createUserID); err != nil {
 		return nil, err
 	}
 
@@ -203,17 +382,80 @@ func (s server) DeletePostsCommentsByUserID(ctx context.Context, req *postservic
 	return res, nil
 }
 
-func makePostModel(gPost *postservice.Post) *model.Post {
+// makeModeratePost モデレーターが投稿を非表示にする、非表示を解除する際の、ステータスのみを変更する更新内容を返す
+// 件名、内容、タグは更新前の値のまま残す
+func (s server) makeModeratePost(ctx context.Context, current model.Post, actor model.User, status uint32, version uint32) (*model.JoinPost, error) {
+	joinPost, err := s.PostUsecase.GetJoinPostByID(ctx, current.ID, current.CreateUserID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.JoinPost{
+		Post: &model.Post{
+			ID:           current.ID,
+			Status:       status,
+			Title:        current.Title,
+			Content:      current.Content,
+			CreateUserID: current.CreateUserID,
+			UpdateUserID: actor.ID,
+			Version:      version,
+		},
+		PostTags: joinPost.PostTags,
+	}, nil
+}
+
+// makePostModel 投稿をモデルに変換する
+// 公開予定日時が日時として不正な場合はエラーを返す
+func makePostModel(gPost *postservice.Post) (*model.Post, error) {
 	post := &model.Post{
-		ID: gPost.GetId(),
-		// Status:       gPost.GetStatus(),
+		ID:           gPost.GetId(),
+		Status:       gPost.GetStatus(),
 		Title:        gPost.GetTitle(),
 		Content:      gPost.GetContent(),
 		Image:        gPost.GetImage(),
 		CreateUserID: gPost.GetCreateUserId(),
 		UpdateUserID: gPost.GetUpdateUserId(),
+		Version:      gPost.GetVersion(),
+	}
+	if gPost.GetPublishAt() != nil {
+		publishAt, err := ptypes.Timestamp(gPost.GetPublishAt())
+		if err != nil {
+			return post, fmt.Errorf("%w: %v", interactor.ErrInvalidPublishAt, err)
+		}
+		post.PublishAt = &publishAt
 	}
-	return post
+	return post, nil
+}
+
+// makePostFilterModel 絞り込み条件をモデルに変換する
+// 作成日時の条件が日時として不正な場合はエラーを返す
+func makePostFilterModel(gFilter *postservice.PostFilter) (model.PostFilter, error) {
+	filter := model.PostFilter{
+		CreateUserIDs:   gFilter.GetCreateUserIds(),
+		TagIDs:          gFilter.GetTagIds(),
+		TagMatch:        model.TagMatch(gFilter.GetTagMatch()),
+		LikedByUserID:   gFilter.GetLikedByUserId(),
+		Image:           model.ImageFilter(gFilter.GetImage()),
+		MinCommentCount: gFilter.GetMinCommentCount(),
+	}
+	if gFilter.GetCreatedAfter() != nil {
+		createdAfter, err := ptypes.Timestamp(gFilter.GetCreatedAfter())
+		if err != nil {
+			return filter, fmt.Errorf("%w: %v", interactor.ErrInvalidCreatedAt, err)
+		}
+		filter.CreatedAfter = &createdAfter
+	}
+	if gFilter.GetCreatedBefore() != nil {
+		createdBefore, err := ptypes.Timestamp(gFilter.GetCreatedBefore())
+		if err != nil {
+			return filter, fmt.Errorf("%w: %v", interactor.ErrInvalidCreatedAt, err)
+		}
+		filter.CreatedBefore = &createdBefore
+	}
+	if gFilter.GetMaxCommentCount() != nil {
+		maxCommentCount := gFilter.GetMaxCommentCount().GetValue()
+		filter.MaxCommentCount = &maxCommentCount
+	}
+	return filter, nil
 }
 
 func makePostTagModel(gPost *postservice.Post) []model.PostTag {
@@ -234,25 +476,29 @@ func makeComment(gComment *postservice.Comment) *model.Comment {
 		PostID:         gComment.PostId,
 		CreateUserID:   gComment.CreateUserId,
 		CommentContent: gComment.Content,
+		Version:        gComment.Version,
 	}
 
 	return comment
 }
 
-func makeGrpcPost(post *model.JoinPost) *postservice.Post {
+func (s server) makeGrpcPost(post *model.JoinPost) *postservice.Post {
 	var tags []uint32
 	var likeUsers []uint32
 	var postComments []*postservice.Comment
-	bucketEndpoint := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", bucket, region)
 	gPost := &postservice.Post{
-		Id: post.Post.ID,
-		// Status:       post.Status,
+		Id:             post.Post.ID,
+		Status:         post.Post.Status,
 		Title:          post.Post.Title,
 		Content:        post.Post.Content,
-		Image:          fmt.Sprintf("%s%s", bucketEndpoint, post.Post.Image),
+		Image:          s.Storage.URL(post.Post.Image),
 		CreateUserId:   post.Post.CreateUserID,
 		CreateUserName: post.User.UserName,
 		UpdateUserId:   post.Post.UpdateUserID,
+		Version:        post.Post.Version,
+	}
+	if post.Post.PublishAt != nil {
+		gPost.PublishAt, _ = ptypes.TimestampProto(*post.Post.PublishAt)
 	}
 	// タグ
 	for _, postTag := range post.PostTags {
@@ -283,6 +529,7 @@ func makeGrpcComment(jc model.JoinComment) *postservice.Comment {
 		CreateUserId:   jc.Comment.CreateUserID,
 		CreateUserName: jc.CreateUser.UserName,
 		Content:        jc.Comment.CommentContent,
+		Version:        jc.Comment.Version,
 	}
 }
 
@@ -323,10 +570,11 @@ func (s server) makeUpdatePostResponse(statusCode string) *postservice.UpdatePos
 }
 
 // makeListPostResponse ListPostメソッドのresponseを生成し返す
-func (s server) makeListPostResponse(posts []*postservice.Post) *postservice.ListPostResponse {
+func (s server) makeListPostResponse(posts []*postservice.Post, nextPageToken string) *postservice.ListPostResponse {
 	res := &postservice.ListPostResponse{
-		Count: uint32(len(posts)),
-		Post:  posts,
+		Count:         uint32(len(posts)),
+		Post:          posts,
+		NextPageToken: nextPageToken,
 	}
 	return res
 }
@@ -367,6 +615,30 @@ func (s server) makeDeletePostResponse(statusCode string) *postservice.DeletePos
 	return res
 }
 
+// makeRestorePostResponse RestorePostメソッドのresponseを生成し返す
+func (s server) makeRestorePostResponse(statusCode string) *postservice.RestorePostResponse {
+	res := &postservice.RestorePostResponse{}
+	if statusCode != "" {
+		responseStatus := &postservice.ResponseStatus{
+			Code: statusCode,
+		}
+		res.Status = responseStatus
+	}
+	return res
+}
+
+// makePurgePostResponse PurgePostメソッドのresponseを生成し返す
+func (s server) makePurgePostResponse(statusCode string) *postservice.PurgePostResponse {
+	res := &postservice.PurgePostResponse{}
+	if statusCode != "" {
+		responseStatus := &postservice.ResponseStatus{
+			Code: statusCode,
+		}
+		res.Status = responseStatus
+	}
+	return res
+}
+
 // makeCreateCommentResponse CreateCommentメソッドのresponseを生成し返す
 func (s server) makeCreateCommentResponse(statusCode string) *postservice.CreateCommentResponse {
 	res := &postservice.CreateCommentResponse{}
@@ -404,7 +676,11 @@ func (s server) makeDeleteCommentResponse(statusCode string) *postservice.Delete
 }
 
 // isBase64 base64データであるか判定
+// 空文字は画像の指定なしとして扱う
 func isBase64(s string) bool {
+	if s == "" {
+		return false
+	}
 	_, err := base64.StdEncoding.DecodeString(s[strings.IndexByte(s, ',')+1:])
 	return err == nil
 }
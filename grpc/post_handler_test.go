@@ -2,25 +2,50 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-playground/assert/v2"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/yzmw1213/PostService/auth"
+	"github.com/yzmw1213/PostService/config"
+	"github.com/yzmw1213/PostService/domain/model"
 	"github.com/yzmw1213/PostService/grpc/postservice"
 	"github.com/yzmw1213/PostService/grpc/tagservice"
+	"github.com/yzmw1213/PostService/search"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/usecase/interactor/memory"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
+// testAuthSecret テスト用サーバーでトークンを検証する共通鍵
+var testAuthSecret = "test-secret"
+
 func init() {
 	lis = bufconn.Listen(bufSize)
-	s := makeServer()
+	verifier, err := auth.NewVerifier(config.Auth{HMACSecret: testAuthSecret})
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := makeServer(verifier)
+	// DBに接続せず、プロセス内のリポジトリでテストする
+	tags := memory.NewTagRepository()
+	posts := memory.NewPostRepository(tags)
+	posts.SearchIndex = search.NewMemoryIndex()
+	testServer := &server{PostUsecase: posts, TagUsecase: tags, Storage: &testStorage{}}
 	// 投稿サービス登録
-	postservice.RegisterPostServiceServer(s, &server{})
+	postservice.RegisterPostServiceServer(s, testServer)
 	// タグサービス登録
-	tagservice.RegisterTagServiceServer(s, &server{})
+	tagservice.RegisterTagServiceServer(s, testServer)
 	go func() {
 		if err := s.Serve(lis); err != nil {
 			log.Fatal(err)
@@ -28,6 +53,39 @@ func init() {
 	}()
 }
 
+// testStorage アップロードした画像を保存せず、連番のキーを返すImageStorage
+type testStorage struct {
+	mu      sync.Mutex
+	uploads int
+}
+
+func (s *testStorage) Upload(ctx context.Context, imageBase64 string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploads++
+	return fmt.Sprintf("images/%d", s.uploads), nil
+}
+
+func (s *testStorage) URL(key string) string {
+	return "https://storage.example.com/" + key
+}
+
+// withUser userIDの一般ユーザーとして認証するトークンを、メタデータに設定したコンテキストを返す
+func withUser(t *testing.T, userID uint32) context.Context {
+	t.Helper()
+	return withAuthority(t, userID, model.AuthorityUser)
+}
+
+// withAuthority 指定した権限のユーザーとして認証するトークンを、メタデータに設定したコンテキストを返す
+func withAuthority(t *testing.T, userID uint32, authority uint32) context.Context {
+	t.Helper()
+	token, err := auth.SignHS256([]byte(testAuthSecret), auth.Principal{UserID: userID, Authority: authority}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), authorizationMetadataKey, "Bearer "+token)
+}
+
 func TestCreatePost(t *testing.T) {
 	var createPosts []*postservice.Post
 	ctx := context.Background()
@@ -62,12 +120,37 @@ func TestCreatePost(t *testing.T) {
 			Post: post,
 		}
 
-		res, err := client.CreatePost(ctx, req)
+		res, err := client.CreatePost(withUser(t, post.GetCreateUserId()), req)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, StatusCreatePostSuccess, res.GetStatus().GetCode())
 	}
 }
 
+// TestCreatePostImage 画像を注入したストレージに保存し、ストレージのURLで返す事をテスト
+func TestCreatePostImage(t *testing.T) {
+	ctx := withUser(t, 666666)
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := postservice.NewPostServiceClient(conn)
+
+	var createUserID uint32 = 666666
+	createPost := &postservice.Post{
+		Title:        "Title",
+		Content:      "Content",
+		Image:        "data:image/png;base64,aW1hZ2U=",
+		CreateUserId: createUserID,
+	}
+	_, err = client.CreatePost(ctx, &postservice.CreatePostRequest{Post: createPost})
+	assert.Equal(t, nil, err)
+	listRes, err := client.ListPost(ctx, &postservice.ListPostRequest{Condition: "create", Id: createUserID})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, strings.HasPrefix(listRes.GetPost()[0].GetImage(), "https://storage.example.com/images/"))
+}
+
 // TestCreatepostContentMax Contentが文字数超過の異常系
 func TestCreatepostContentMax(t *testing.T) {
 	var createPost = &postservice.Post{
@@ -75,7 +158,7 @@ func TestCreatepostContentMax(t *testing.T) {
 		Content:      "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
 		CreateUserId: 555555,
 	}
-	ctx := context.Background()
+	ctx := withUser(t, 555555)
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatal(err)
@@ -87,31 +170,36 @@ func TestCreatepostContentMax(t *testing.T) {
 	req := &postservice.CreatePostRequest{
 		Post: createPost,
 	}
-	_, err = client.CreatePost(context.Background(), req)
+	_, err = client.CreatePost(ctx, req)
 
 	assert.NotEqual(t, nil, err)
 
 	f, d := getErrorDetail(err)
 
-	assert.Equal(t, "Content", f)
-	assert.Equal(t, StatusPostContentStringCount, d)
+	assert.Equal(t, "post.content", f)
+	assert.Equal(t, "max=240", d)
+	assert.Equal(t, StatusPostContentStringCount, status.Convert(err).Message())
 }
 
 func getErrorDetail(err error) (string, string) {
-	var field string
-	var description string
+	violations := getFieldViolations(err)
+	if len(violations) == 0 {
+		return "", ""
+	}
+	return violations[0].GetField(), violations[0].GetDescription()
+}
+
+// getFieldViolations エラーの詳細に含まれるFieldViolationを全て返す
+func getFieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
 	st, _ := status.FromError(err)
 	for _, detail := range st.Details() {
 		switch dType := detail.(type) {
 		case *errdetails.BadRequest:
-			for _, violation := range dType.GetFieldViolations() {
-				field = violation.GetField()
-				description = violation.GetDescription()
-			}
+			violations = append(violations, dType.GetFieldViolations()...)
 		}
 	}
-
-	return field, description
+	return violations
 }
 
 // // TestCreatePostContentNull TitleがNullの異常系
@@ -121,7 +209,7 @@ func TestCreatePostContentNull(t *testing.T) {
 		Content:      "",
 		CreateUserId: 666666,
 	}
-	ctx := context.Background()
+	ctx := withUser(t, 666666)
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatal(err)
@@ -133,14 +221,13 @@ func TestCreatePostContentNull(t *testing.T) {
 	req := &postservice.CreatePostRequest{
 		Post: createPost,
 	}
-	_, err = client.CreatePost(context.Background(), req)
+	_, err = client.CreatePost(ctx, req)
 	assert.NotEqual(t, nil, err)
 
 	f, d := getErrorDetail(err)
 
-	assert.Equal(t, "Content", f)
-	assert.Equal(t, StatusPostContentStringCount, d)
-
+	assert.Equal(t, "post.content", f)
+	assert.Equal(t, "min=1", d)
 }
 
 // TestCreatePostTitleMax Titleが文字数超過の異常系
@@ -150,7 +237,7 @@ func TestCreatePostTitleMax(t *testing.T) {
 		Content:      "Content",
 		CreateUserId: 555555,
 	}
-	ctx := context.Background()
+	ctx := withUser(t, 555555)
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatal(err)
@@ -162,14 +249,14 @@ func TestCreatePostTitleMax(t *testing.T) {
 	req := &postservice.CreatePostRequest{
 		Post: createPost,
 	}
-	_, err = client.CreatePost(context.Background(), req)
+	_, err = client.CreatePost(ctx, req)
 
 	assert.NotEqual(t, nil, err)
 
 	f, d := getErrorDetail(err)
 
-	assert.Equal(t, "Title", f)
-	assert.Equal(t, StatusPostTitleStringCount, d)
+	assert.Equal(t, "post.title", f)
+	assert.Equal(t, "max=32", d)
 }
 
 // TestCreatePostTitleNull Titleが空白の異常系
@@ -179,7 +266,7 @@ func TestCreatePostTitleNull(t *testing.T) {
 		Content:      "Content",
 		CreateUserId: 555555,
 	}
-	ctx := context.Background()
+	ctx := withUser(t, 555555)
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatal(err)
@@ -191,19 +278,77 @@ func TestCreatePostTitleNull(t *testing.T) {
 	req := &postservice.CreatePostRequest{
 		Post: createPost,
 	}
-	_, err = client.CreatePost(context.Background(), req)
+	_, err = client.CreatePost(ctx, req)
 
 	assert.NotEqual(t, nil, err)
 
 	f, d := getErrorDetail(err)
 
-	assert.Equal(t, "Title", f)
-	assert.Equal(t, StatusPostTitleStringCount, d)
+	assert.Equal(t, "post.title", f)
+	assert.Equal(t, "min=1", d)
+	assert.Equal(t, StatusPostTitleStringCount, status.Convert(err).Message())
+}
+
+// TestCreatePostViolations 件名と投稿内容が空白の場合に、両方の検証エラーを返す
+func TestCreatePostViolations(t *testing.T) {
+	var createPost = &postservice.Post{
+		Title:        "",
+		Content:      "",
+		CreateUserId: 555555,
+	}
+	ctx := withUser(t, 555555)
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := postservice.NewPostServiceClient(conn)
+
+	req := &postservice.CreatePostRequest{
+		Post: createPost,
+	}
+	_, err = client.CreatePost(ctx, req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	violations := getFieldViolations(err)
+	assert.Equal(t, 2, len(violations))
+	assert.Equal(t, "post.title", violations[0].GetField())
+	assert.Equal(t, "min=1", violations[0].GetDescription())
+	assert.Equal(t, "post.content", violations[1].GetField())
+	assert.Equal(t, "min=1", violations[1].GetDescription())
+}
+
+// TestCreatePostInvalidPublishAt 不正な公開予定日時を指定した場合、投稿を作成せずにエラーを返す事をテスト
+func TestCreatePostInvalidPublishAt(t *testing.T) {
+	var createUserID uint32 = 565656
+	ctx := withUser(t, createUserID)
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := postservice.NewPostServiceClient(conn)
+
+	createPost := &postservice.Post{
+		Title:     "Title",
+		Content:   "Content",
+		PublishAt: &timestamp.Timestamp{Seconds: 1, Nanos: -1},
+	}
+	_, err = client.CreatePost(ctx, createPostRequest(createPost))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, description := getErrorDetail(err)
+	assert.Equal(t, interactor.ErrInvalidPublishAt.Error(), description)
+
+	listRes, err := client.ListPost(ctx, &postservice.ListPostRequest{Condition: "create", Id: createUserID})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(listRes.GetPost()))
 }
 
 func TestCreatePostTag(t *testing.T) {
 	// var createPost *postservice.Post
-	ctx := context.Background()
+	ctx := withUser(t, 111111)
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatal(err)
@@ -229,3 +374,135 @@ func TestCreatePostTag(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, StatusCreatePostSuccess, res.GetStatus().GetCode())
 }
+
+// TestListAndReadPost 作成した投稿が一覧、詳細で取得できる事をテスト
+func TestListAndReadPost(t *testing.T) {
+	ctx := withUser(t, 777777)
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := postservice.NewPostServiceClient(conn)
+
+	var createUserID uint32 = 777777
+	createPost := &postservice.Post{
+		Title:        "Title",
+		Content:      "Content",
+		CreateUserId: createUserID,
+	}
+	_, err = client.CreatePost(ctx, &postservice.CreatePostRequest{Post: createPost})
+	assert.Equal(t, nil, err)
+
+	listReq := &postservice.ListPostRequest{
+		Condition: "create",
+		Id:        createUserID,
+	}
+	listRes, err := client.ListPost(ctx, listReq)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(listRes.GetPost()))
+
+	id := listRes.GetPost()[0].GetId()
+	readRes, err := client.ReadPost(ctx, &postservice.ReadPostRequest{Id: id})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Content", readRes.GetPost().GetContent())
+	assert.Equal(t, createUserID, readRes.GetPost().GetCreateUserId())
+
+	_, err = client.DeletePost(ctx, &postservice.DeletePostRequest{Id: id})
+	assert.Equal(t, nil, err)
+	_, err = client.ReadPost(ctx, &postservice.ReadPostRequest{Id: id})
+	assert.NotEqual(t, nil, err)
+}
+
+// TestListPostInvalidCreatedAt 日時として不正な作成日時の条件を指定した場合、InvalidArgumentを返す事をテスト
+func TestListPostInvalidCreatedAt(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := postservice.NewPostServiceClient(conn)
+
+	invalid := &timestamp.Timestamp{Seconds: 1, Nanos: -1}
+	for _, filter := range []*postservice.PostFilter{{CreatedAfter: invalid}, {CreatedBefore: invalid}} {
+		_, err = client.ListPost(ctx, &postservice.ListPostRequest{Filter: filter})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, description := getErrorDetail(err)
+		assert.Equal(t, interactor.ErrInvalidCreatedAt.Error(), description)
+	}
+}
+
+// TestUpdatePostWithoutVersion 版数を指定しない更新、差し戻しは確認せずに上書きせず、InvalidArgumentを返す事をテスト
+func TestUpdatePostWithoutVersion(t *testing.T) {
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := postservice.NewPostServiceClient(conn)
+	f := newPolicyFixture(t, client, tagservice.NewTagServiceClient(conn))
+	owner := withUser(t, f.ownerID)
+
+	_, err = client.UpdatePost(owner, &postservice.UpdatePostRequest{Post: &postservice.Post{Id: f.postID, Title: "Title", Content: "Updated"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, description := getErrorDetail(err)
+	assert.Equal(t, interactor.ErrVersionRequired.Error(), description)
+
+	_, err = client.UpdateComment(owner, &postservice.UpdateCommentRequest{Comment: &postservice.Comment{Id: f.commentID, Content: "Updated"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.RevertPostToRevision(owner, &postservice.RevertPostToRevisionRequest{PostId: f.postID, Revision: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	readRes, err := client.ReadPost(owner, &postservice.ReadPostRequest{Id: f.postID})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Content", readRes.GetPost().GetContent())
+	assert.Equal(t, f.version, readRes.GetPost().GetVersion())
+}
+
+func TestLikePostTwiceAndCheckIntegrity(t *testing.T) {
+	ctx := withUser(t, 888888)
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := postservice.NewPostServiceClient(conn)
+
+	var createUserID uint32 = 888888
+	createPost := &postservice.Post{
+		Title:        "Title",
+		Content:      "Content",
+		CreateUserId: createUserID,
+	}
+	_, err = client.CreatePost(ctx, &postservice.CreatePostRequest{Post: createPost})
+	assert.Equal(t, nil, err)
+	listRes, err := client.ListPost(ctx, &postservice.ListPostRequest{Condition: "create", Id: createUserID})
+	assert.Equal(t, nil, err)
+	id := listRes.GetPost()[0].GetId()
+
+	// 同じユーザーは1回のみお気に入りできる
+	_, err = client.LikePost(ctx, &postservice.LikePostRequest{Id: id, UserId: createUserID})
+	assert.Equal(t, nil, err)
+	_, err = client.LikePost(ctx, &postservice.LikePostRequest{Id: id, UserId: createUserID})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	assert.Equal(t, StatusAlreadyLiked, st.Message())
+
+	// 修復後は参照先のない紐付け情報が残らない
+	ctx = withAuthority(t, 1, model.AuthorityAdmin)
+	res, err := client.CheckIntegrity(ctx, &postservice.CheckIntegrityRequest{Repair: true})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, StatusCheckIntegritySuccess, res.GetStatus().GetCode())
+	for _, issue := range res.GetIssues() {
+		assert.Equal(t, true, issue.GetRepaired())
+	}
+	res, err = client.CheckIntegrity(ctx, &postservice.CheckIntegrityRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(res.GetIssues()))
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/grpc/postservice"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/usecase/policy"
+)
+
+const (
+	// StatusRevertPostSuccess 投稿差し戻し成功ステータス
+	StatusRevertPostSuccess string = "POST_REVERT_SUCCESS"
+)
+
+// ListPostRevisions 投稿の更新履歴を新しい順に返す
+func (s server) ListPostRevisions(ctx context.Context, req *postservice.ListPostRevisionsRequest) (*postservice.ListPostRevisionsResponse, error) {
+	var revisions []*postservice.PostRevision
+	if err := s.checkPostHistoryViewer(ctx, req.GetPostId()); err != nil {
+		return nil, err
+	}
+	rows, err := s.PostUsecase.ListRevisions(ctx, req.GetPostId())
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		revisions = append(revisions, makeGrpcPostRevision(row))
+	}
+	res := &postservice.ListPostRevisionsResponse{
+		Revisions: revisions,
+	}
+	return res, nil
+}
+
+// GetPostRevision 投稿の更新履歴1件と直前の版との差分を返す
+func (s server) GetPostRevision(ctx context.Context, req *postservice.GetPostRevisionRequest) (*postservice.GetPostRevisionResponse, error) {
+	if err := s.checkPostHistoryViewer(ctx, req.GetPostId()); err != nil {
+		return nil, err
+	}
+	row, diff, err := s.PostUsecase.GetRevision(ctx, req.GetPostId(), req.GetRevision())
+	if err != nil {
+		return nil, err
+	}
+	res := &postservice.GetPostRevisionResponse{
+		Revision: makeGrpcPostRevision(row),
+		Diff:     makeGrpcPostRevisionDiff(diff),
+	}
+	return res, nil
+}
+
+// RevertPostToRevision 投稿を指定した版の内容に戻す
+func (s server) RevertPostToRevision(ctx context.Context, req *postservice.RevertPostToRevisionRequest) (*postservice.RevertPostToRevisionResponse, error) {
+	post, err := s.PostUsecase.GetByID(ctx, req.GetPostId())
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.CanRevertPost(actingUser(ctx), post); err != nil {
+		return nil, err
+	}
+	if _, err := s.PostUsecase.RevertToRevision(ctx, req.GetPostId(), req.GetRevision(), req.GetVersion(), actingUserID(ctx)); err != nil {
+		return nil, err
+	}
+	res := &postservice.RevertPostToRevisionResponse{
+		Status: &postservice.ResponseStatus{
+			Code: StatusRevertPostSuccess,
+		},
+	}
+	return res, nil
+}
+
+// checkPostHistoryViewer リクエストしたユーザーが投稿の更新履歴を参照できるか判定する
+// 投稿者本人とモデレーターは全ての投稿、それ以外は閲覧できる投稿のみ参照でき、参照できない投稿は存在しないものとして扱う
+func (s server) checkPostHistoryViewer(ctx context.Context, postID uint32) error {
+	actor := actingUser(ctx)
+	post, err := s.PostUsecase.GetByID(ctx, postID)
+	if interactor.KindOf(err) == interactor.KindNotFound {
+		// ゴミ箱に移動した投稿
+		trashed, trashedErr := s.PostUsecase.GetTrashedByID(ctx, postID)
+		if trashedErr != nil || policy.CanViewPostHistory(actor, trashed) != nil {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if policy.CanViewPostHistory(actor, post) == nil {
+		return nil
+	}
+	_, err = s.PostUsecase.GetJoinPostByID(ctx, postID, actor.ID)
+	return err
+}
+
+func makeGrpcPostRevision(row model.JoinPostRevision) *postservice.PostRevision {
+	createdAt, _ := ptypes.TimestampProto(row.Revision.CreatedAt)
+	return &postservice.PostRevision{
+		PostId:       row.Revision.PostID,
+		Revision:     row.Revision.Revision,
+		Title:        row.Revision.Title,
+		Content:      row.Revision.Content,
+		Tags:         row.TagIDs,
+		UpdateUserId: row.Revision.UpdateUserID,
+		CreatedAt:    createdAt,
+	}
+}
+
+func makeGrpcPostRevisionDiff(diff model.PostRevisionDiff) *postservice.PostRevisionDiff {
+	return &postservice.PostRevisionDiff{
+		PreviousRevision: diff.PreviousRevision,
+		TitleChanged:     diff.TitleChanged,
+		PreviousTitle:    diff.PreviousTitle,
+		ContentChanged:   diff.ContentChanged,
+		PreviousContent:  diff.PreviousContent,
+		AddedTags:        diff.AddedTagIDs,
+		RemovedTags:      diff.RemovedTagIDs,
+	}
+}
@@ -1,65 +1,140 @@
 package grpc
 
 import (
-	"fmt"
 	"log"
 	"net"
-	"os"
-	"os/signal"
+	"time"
 
+	"github.com/yzmw1213/PostService/auth"
+	"github.com/yzmw1213/PostService/config"
 	"github.com/yzmw1213/PostService/grpc/postservice"
 	"github.com/yzmw1213/PostService/grpc/tagservice"
-	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/healthcheck"
+	"github.com/yzmw1213/PostService/usecase/repository"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type server struct {
-	PostUsecase interactor.PostInteractor
-	TagUsecase  interactor.TagInteractor
+	PostUsecase repository.PostRepository
+	TagUsecase  repository.TagRepository
+	Storage     repository.ImageStorage
 }
 
-// NewPostGrpcServer gRPCサーバー起動
-func NewPostGrpcServer() {
-	lis, err := net.Listen("tcp", "0.0.0.0:50053")
+// PostGrpcServer 投稿サービスのgRPCサーバー
+type PostGrpcServer struct {
+	grpcServer      *grpc.Server
+	health          *health.Server
+	lis             net.Listener
+	shutdownTimeout time.Duration
+	// services ヘルスチェックで状態を返すサービス名
+	services []string
+}
+
+// dependencyServicePrefix 依存先の状態をヘルスチェックで返す際のサービス名の接頭辞
+const dependencyServicePrefix = "dependency."
+
+var _ healthcheck.Reporter = (*PostGrpcServer)(nil)
+
+// NewPostGrpcServer 設定で指定されたアドレスで待ち受けるgRPCサーバーを生成する
+func NewPostGrpcServer(cfg config.Server, verifier *auth.Verifier, postUsecase repository.PostRepository, tagUsecase repository.TagRepository, storage repository.ImageStorage) (*PostGrpcServer, error) {
+	lis, err := net.Listen("tcp", cfg.Address)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		return nil, err
 	}
+	return newPostGrpcServer(lis, cfg.ShutdownTimeout, verifier, &server{PostUsecase: postUsecase, TagUsecase: tagUsecase, Storage: storage}), nil
+}
 
-	server := &server{}
-
-	s := makeServer()
+// newPostGrpcServer lisで待ち受けるgRPCサーバーに各サービスを登録する
+func newPostGrpcServer(lis net.Listener, shutdownTimeout time.Duration, verifier *auth.Verifier, server *server) *PostGrpcServer {
+	s := makeServer(verifier)
 
 	// 投稿サービス登録
 	postservice.RegisterPostServiceServer(s, server)
 	// タグサービス登録
 	tagservice.RegisterTagServiceServer(s, server)
+	var services []string
+	for name := range s.GetServiceInfo() {
+		services = append(services, name)
+	}
+	// ヘルスチェック登録
+	healthServer := health.NewServer()
+	for _, name := range services {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(s, healthServer)
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
+
+	return &PostGrpcServer{
+		grpcServer:      s,
+		health:          healthServer,
+		lis:             lis,
+		shutdownTimeout: shutdownTimeout,
+		services:        services,
+	}
+}
+
+// Report 依存先の検査結果をヘルスチェックに反映する
+// 必須の依存先に接続できない場合のみ、サーバー全体と各サービスをNOT_SERVINGにする
+// 必須でない依存先に接続できない場合(degraded)は、サービスはSERVINGのまま依存先のみNOT_SERVINGにする
+func (p *PostGrpcServer) Report(report healthcheck.Report) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if report.Status == healthcheck.StatusFailed {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	p.health.SetServingStatus("", status)
+	for _, name := range p.services {
+		p.health.SetServingStatus(name, status)
+	}
+	for _, result := range report.Results {
+		dependencyStatus := healthpb.HealthCheckResponse_SERVING
+		if result.Err != nil {
+			dependencyStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		p.health.SetServingStatus(dependencyServicePrefix+result.Name, dependencyStatus)
+	}
+}
+
+// Serve リクエストの受付を開始する。Shutdownで停止するまで戻らない
+func (p *PostGrpcServer) Serve() error {
 	log.Println("main grpc server has started")
+	return p.grpcServer.Serve(p.lis)
+}
+
+// Shutdown ヘルスチェックをNOT_SERVINGにしてから、処理中のリクエストの完了を待って停止する
+// shutdownTimeoutを過ぎても完了しないリクエストは強制的に中断する
+func (p *PostGrpcServer) Shutdown() {
+	log.Println("Stopping the server")
+	// 新しいリクエストが振り分けられないように、停止中である事を先に通知する
+	p.health.Shutdown()
 
+	stopped := make(chan struct{})
 	go func() {
-		if err := s.Serve(lis); err != nil {
-			log.Fatalf("failed to serve: %v", err)
-		}
+		p.grpcServer.GracefulStop()
+		close(stopped)
 	}()
 
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, os.Interrupt)
-
-	// Block until a sgnal is received
-	<-ch
-	fmt.Println("Stopping the server")
-	s.Stop()
-	fmt.Println("Closing the client")
-	lis.Close()
-	fmt.Println("End of Program")
+	timer := time.NewTimer(p.shutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+		log.Println("main grpc server has stopped")
+	case <-timer.C:
+		log.Printf("drain timeout (%v) exceeded, cancelling in-flight requests\n", p.shutdownTimeout)
+		p.grpcServer.Stop()
+		<-stopped
+	}
 }
 
-func makeServer() *grpc.Server {
+// makeServer リクエストのトークンをverifierで検証するgRPCサーバーを生成する
+// 認証のエラーもステータスに変換するため、transmitStatusInterceptorを先に実行する
+func makeServer(verifier *auth.Verifier) *grpc.Server {
 	s := grpc.NewServer(
-		grpc.UnaryInterceptor(grpc.UnaryServerInterceptor(transmitStatusInterceptor)),
+		grpc.ChainUnaryInterceptor(transmitStatusInterceptor, authInterceptor(verifier)),
 	)
 
 	return s
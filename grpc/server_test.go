@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/auth"
+	"github.com/yzmw1213/PostService/config"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/grpc/postservice"
+	"github.com/yzmw1213/PostService/healthcheck"
+	"github.com/yzmw1213/PostService/usecase/interactor/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// blockingPostRepository Createを呼ばれるとreleaseが閉じられるまで完了しない投稿リポジトリ
+type blockingPostRepository struct {
+	*memory.PostRepository
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingPostRepository) Create(ctx context.Context, post *model.JoinPost) (*model.JoinPost, error) {
+	close(r.started)
+	select {
+	case <-r.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return r.PostRepository.Create(ctx, post)
+}
+
+// startShutdownTestServer Createが完了しないgRPCサーバーを起動し、接続したクライアントを返す
+func startShutdownTestServer(t *testing.T, shutdownTimeout time.Duration) (*PostGrpcServer, *blockingPostRepository, postservice.PostServiceClient) {
+	tags := memory.NewTagRepository()
+	posts := &blockingPostRepository{
+		PostRepository: memory.NewPostRepository(tags),
+		started:        make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+	listener := bufconn.Listen(bufSize)
+	verifier, err := auth.NewVerifier(config.Auth{HMACSecret: testAuthSecret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newPostGrpcServer(listener, shutdownTimeout, verifier, &server{PostUsecase: posts, TagUsecase: tags, Storage: &testStorage{}})
+	go p.Serve()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.Dial()
+	}), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return p, posts, postservice.NewPostServiceClient(conn)
+}
+
+// createPostAsync CreatePostを別のgoroutineで呼び出し、結果を返すチャネルを返す
+func createPostAsync(t *testing.T, client postservice.PostServiceClient) chan error {
+	ctx := withUser(t, one)
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.CreatePost(ctx, &postservice.CreatePostRequest{Post: &postservice.Post{
+			Title:        "Title",
+			Content:      "Content",
+			CreateUserId: one,
+		}})
+		result <- err
+	}()
+	return result
+}
+
+// TestShutdownDrainsInFlightRequest 停止中も処理中のCreatePostは完了する事をテスト
+func TestShutdownDrainsInFlightRequest(t *testing.T) {
+	p, posts, client := startShutdownTestServer(t, 10*time.Second)
+	result := createPostAsync(t, client)
+	<-posts.started
+
+	stopped := make(chan struct{})
+	go func() {
+		p.Shutdown()
+		close(stopped)
+	}()
+
+	// 処理中のリクエストの完了を待つ間は、NOT_SERVINGを返す
+	assert.Equal(t, true, waitForHealth(p, healthpb.HealthCheckResponse_NOT_SERVING))
+	select {
+	case <-stopped:
+		t.Fatal("処理中のリクエストの完了前に停止しました")
+	default:
+	}
+
+	close(posts.release)
+	assert.Equal(t, nil, <-result)
+	<-stopped
+	list, _, err := posts.List(context.Background(), model.PostListCondition{Condition: "create", ID: one}, model.Pagination{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(list))
+}
+
+// TestShutdownTimeout 待機時間を過ぎたリクエストは中断して停止する事をテスト
+func TestShutdownTimeout(t *testing.T) {
+	p, posts, client := startShutdownTestServer(t, 100*time.Millisecond)
+	defer close(posts.release)
+	result := createPostAsync(t, client)
+	<-posts.started
+
+	p.Shutdown()
+	st, _ := status.FromError(<-result)
+	assert.Equal(t, codes.Unavailable, st.Code())
+}
+
+// waitForHealth ヘルスチェックが指定した状態になるまで待つ
+func waitForHealth(p *PostGrpcServer, want healthpb.HealthCheckResponse_ServingStatus) bool {
+	for i := 0; i < 100; i++ {
+		res, err := p.health.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err == nil && res.GetStatus() == want {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+// TestReportHealth 依存先の検査結果をサービスごとのヘルスチェックに反映する事をテスト
+func TestReportHealth(t *testing.T) {
+	p, _, _ := startShutdownTestServer(t, time.Second)
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		res, err := p.health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.GetStatus()
+	}
+	failure := errors.New("unreachable")
+
+	// 必須でない依存先のみ接続できない場合は、サービスはSERVINGのまま依存先のみNOT_SERVINGになる
+	p.Report(healthcheck.Report{Status: healthcheck.StatusDegraded, Results: []healthcheck.Result{
+		{Name: "db", Critical: true},
+		{Name: "storage", Err: failure},
+	}})
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("postservice.PostService"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("tagservice.TagService"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("dependency.db"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("dependency.storage"))
+
+	// 必須の依存先に接続できない場合は、全てのサービスがNOT_SERVINGになる
+	p.Report(healthcheck.Report{Status: healthcheck.StatusFailed, Results: []healthcheck.Result{
+		{Name: "db", Critical: true, Err: failure},
+		{Name: "storage"},
+	}})
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("postservice.PostService"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check("dependency.db"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("dependency.storage"))
+
+	// 停止後は検査結果を反映しない
+	p.Shutdown()
+	p.Report(healthcheck.Report{Status: healthcheck.StatusServing})
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/yzmw1213/PostService/domain/model"
 	"github.com/yzmw1213/PostService/grpc/tagservice"
+	"github.com/yzmw1213/PostService/usecase/policy"
 )
 
 const (
@@ -25,14 +26,19 @@ const (
 
 func (s server) CreateTag(ctx context.Context, req *tagservice.CreateTagRequest) (*tagservice.CreateTagResponse, error) {
 	postData := req.GetTag()
+	if err := policy.CanManageTag(actingUser(ctx), 0); err != nil {
+		return nil, err
+	}
 	tag := makeTagModel(postData)
+	// 作成ユーザーはリクエストの値ではなく、認証したユーザーとする
+	tag.CreateUserID = actingUserID(ctx)
 
 	// 既に同一のtagnameによる登録がないかチェック
-	if s.tagExistsByTagName(tag.TagName) == true {
+	if s.tagExistsByTagName(ctx, tag.TagName) == true {
 		return s.makeCreateTagResponse(StatustagNameAlreadyUsed), nil
 	}
 
-	tag, err := s.TagUsecase.Create(tag)
+	tag, err := s.TagUsecase.Create(ctx, tag)
 	if err != nil {
 		return nil, err
 	}
@@ -42,14 +48,17 @@ func (s server) CreateTag(ctx context.Context, req *tagservice.CreateTagRequest)
 
 func (s server) DeleteTag(ctx context.Context, req *tagservice.DeleteTagRequest) (*tagservice.DeleteTagResponse, error) {
 	id := req.GetTagId()
+	if err := policy.CanManageTag(actingUser(ctx), id); err != nil {
+		return nil, err
+	}
 
 	// 既にタグが削除されていないかチェック
-	if s.tagExistsByTagID(id) != true {
+	if s.tagExistsByTagID(ctx, id) != true {
 		log.Println("tag not exists")
 		return s.makeDeleteTagResponse(StatusTagNotExists), nil
 	}
 
-	err = s.TagUsecase.DeleteByID(id)
+	err = s.TagUsecase.DeleteByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -61,8 +70,12 @@ func (s server) UpdateTag(ctx context.Context, req *tagservice.UpdateTagRequest)
 	postData := req.GetTag()
 
 	tag := makeTagModel(postData)
+	if err := policy.CanManageTag(actingUser(ctx), tag.ID); err != nil {
+		return nil, err
+	}
+	tag.UpdateUserID = actingUserID(ctx)
 
-	if _, err := s.TagUsecase.Update(tag); err != nil {
+	if _, err := s.TagUsecase.Update(ctx, tag); err != nil {
 		return nil, err
 	}
 
@@ -71,7 +84,7 @@ func (s server) UpdateTag(ctx context.Context, req *tagservice.UpdateTagRequest)
 
 // ListTag 全てのタグを取得して返す
 func (s server) ListTag(ctx context.Context, req *tagservice.ListTagRequest) (*tagservice.ListTagResponse, error) {
-	rows, err := s.TagUsecase.List()
+	rows, err := s.TagUsecase.List(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +101,7 @@ func (s server) ListTag(ctx context.Context, req *tagservice.ListTagRequest) (*t
 
 // ListValidTag 公開ステータスが公開のタグを取得して返す
 func (s server) ListValidTag(ctx context.Context, req *tagservice.ListValidTagRequest) (*tagservice.ListValidTagResponse, error) {
-	rows, err := s.TagUsecase.ListValidTag()
+	rows, err := s.TagUsecase.ListValidTag(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -162,11 +175,11 @@ func (s server) makeUpdateTagResponse(statusCode string) *tagservice.UpdateTagRe
 }
 
 // tagExistsByTagName 同名のタグが登録済みかの判定
-func (s server) tagExistsByTagName(tagName string) bool {
+func (s server) tagExistsByTagName(ctx context.Context, tagName string) bool {
 	if tagName == "" {
 		return false
 	}
-	tag, _ := s.TagUsecase.GetTagByTagName(tagName)
+	tag, _ := s.TagUsecase.GetTagByTagName(ctx, tagName)
 	if tag.ID == 0 {
 		return false
 	}
@@ -174,8 +187,8 @@ func (s server) tagExistsByTagName(tagName string) bool {
 }
 
 // tagExistsByTagID　IDが一致するタグの登録があるかの判定
-func (s server) tagExistsByTagID(tagID uint32) bool {
-	tag, _ := s.TagUsecase.GetTagByTagID(tagID)
+func (s server) tagExistsByTagID(ctx context.Context, tagID uint32) bool {
+	tag, _ := s.TagUsecase.GetTagByTagID(ctx, tagID)
 	if tag.ID == 0 {
 		return false
 	}
@@ -5,13 +5,15 @@ import (
 	"testing"
 
 	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
 	"github.com/yzmw1213/PostService/grpc/tagservice"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 func TestCreate(t *testing.T) {
 	var createTag *tagservice.Tag
-	ctx := context.Background()
+	ctx := withAuthority(t, 1, model.AuthorityAdmin)
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatal(err)
@@ -38,7 +40,7 @@ func TestCreate(t *testing.T) {
 
 func TestCreateTagNameNull(t *testing.T) {
 	var createTag *tagservice.Tag
-	ctx := context.Background()
+	ctx := withAuthority(t, 1, model.AuthorityAdmin)
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatal(err)
@@ -62,8 +64,9 @@ func TestCreateTagNameNull(t *testing.T) {
 	f, d := getErrorDetail(err)
 
 	assert.NotEqual(t, nil, err)
-	assert.Equal(t, "TagName", f)
-	assert.Equal(t, StatusTagNameStringCount, d)
+	assert.Equal(t, "tag.tag_name", f)
+	assert.Equal(t, "min=1", d)
+	assert.Equal(t, StatusTagNameStringCount, status.Convert(err).Message())
 }
 
 func TestList(t *testing.T) {
@@ -0,0 +1,151 @@
+package healthcheck
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yzmw1213/PostService/scheduler"
+)
+
+// Status 依存先の検査結果から判定したサービスの状態
+type Status int
+
+const (
+	// StatusServing 全ての依存先に接続できる
+	StatusServing Status = iota
+	// StatusDegraded 必須でない依存先に接続できないが、リクエストは処理できる
+	StatusDegraded
+	// StatusFailed 必須の依存先に接続できず、リクエストを処理できない
+	StatusFailed
+)
+
+// String 状態をログに出力する文字列を返す
+func (s Status) String() string {
+	switch s {
+	case StatusServing:
+		return "serving"
+	case StatusDegraded:
+		return "degraded"
+	case StatusFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// Dependency 定期的に接続を検査する依存先
+type Dependency struct {
+	Name string
+	// Critical 接続できない場合にリクエストを処理できない依存先か
+	Critical bool
+	// Check 依存先に接続できない場合はエラーを返す
+	Check func(ctx context.Context) error
+}
+
+// Result 依存先1つの検査結果
+type Result struct {
+	Name     string
+	Critical bool
+	Err      error
+}
+
+// Report 全ての依存先の検査結果
+type Report struct {
+	Status  Status
+	Results []Result
+}
+
+// Reporter 検査結果の通知先
+type Reporter interface {
+	Report(report Report)
+}
+
+// Monitor 依存先を一定間隔で検査し、結果をReporterに通知する
+type Monitor struct {
+	reporter     Reporter
+	timeout      time.Duration
+	dependencies []Dependency
+	scheduler    *scheduler.Scheduler
+
+	mu   sync.Mutex
+	last Status
+}
+
+// NewMonitor intervalごとに依存先を検査するMonitorを生成する
+// 依存先1つあたりの検査はtimeoutで打ち切る
+func NewMonitor(reporter Reporter, interval time.Duration, timeout time.Duration, dependencies ...Dependency) *Monitor {
+	m := &Monitor{
+		reporter:     reporter,
+		timeout:      timeout,
+		dependencies: dependencies,
+	}
+	m.scheduler = scheduler.New("health check", interval, func(ctx context.Context, now time.Time) error {
+		m.Check(ctx)
+		return nil
+	})
+	return m
+}
+
+// Start 1回目の検査を実行し、定期的な検査を開始する
+func (m *Monitor) Start() {
+	m.Check(context.Background())
+	m.scheduler.Start()
+}
+
+// Stop 定期的な検査を停止する
+func (m *Monitor) Stop() {
+	m.scheduler.Stop()
+}
+
+// Check 全ての依存先を並行して検査し、結果をReporterに通知する
+func (m *Monitor) Check(ctx context.Context) Report {
+	results := make([]Result, len(m.dependencies))
+	var wg sync.WaitGroup
+	for i, dependency := range m.dependencies {
+		wg.Add(1)
+		go func(i int, dependency Dependency) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, m.timeout)
+			defer cancel()
+			results[i] = Result{Name: dependency.Name, Critical: dependency.Critical, Err: dependency.Check(checkCtx)}
+		}(i, dependency)
+	}
+	wg.Wait()
+
+	report := Report{Status: Evaluate(results), Results: results}
+	m.logChange(report)
+	m.reporter.Report(report)
+	return report
+}
+
+// Evaluate 検査結果からサービスの状態を判定する
+func Evaluate(results []Result) Status {
+	status := StatusServing
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+		if result.Critical {
+			return StatusFailed
+		}
+		status = StatusDegraded
+	}
+	return status
+}
+
+// logChange 状態が変わった場合に、接続できない依存先と共にログに出力する
+func (m *Monitor) logChange(report Report) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if report.Status == m.last {
+		return
+	}
+	m.last = report.Status
+	log.Printf("health status changed to %v\n", report.Status)
+	for _, result := range report.Results {
+		if result.Err != nil {
+			log.Printf("health check %s failed: %v\n", result.Name, result.Err)
+		}
+	}
+}
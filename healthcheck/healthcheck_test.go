@@ -0,0 +1,71 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+var errUnreachable = errors.New("unreachable")
+
+// recordReporter 通知された検査結果を記録するReporter
+type recordReporter struct {
+	reports []Report
+}
+
+func (r *recordReporter) Report(report Report) {
+	r.reports = append(r.reports, report)
+}
+
+// TestEvaluate 必須の依存先の失敗のみfailedになる事をテスト
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []Result
+		want    Status
+	}{
+		{"all reachable", []Result{{Name: "db", Critical: true}, {Name: "storage"}}, StatusServing},
+		{"optional unreachable", []Result{{Name: "db", Critical: true}, {Name: "storage", Err: errUnreachable}}, StatusDegraded},
+		{"critical unreachable", []Result{{Name: "db", Critical: true, Err: errUnreachable}, {Name: "storage", Err: errUnreachable}}, StatusFailed},
+		{"no dependencies", nil, StatusServing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Evaluate(tt.results))
+		})
+	}
+}
+
+// TestMonitorCheck 全ての依存先の結果を通知し、応答のない依存先は打ち切る事をテスト
+func TestMonitorCheck(t *testing.T) {
+	reporter := &recordReporter{}
+	m := NewMonitor(reporter, time.Hour, 50*time.Millisecond,
+		Dependency{Name: "db", Critical: true, Check: func(ctx context.Context) error { return nil }},
+		Dependency{Name: "user", Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	)
+
+	report := m.Check(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, 1, len(reporter.reports))
+	assert.Equal(t, "db", report.Results[0].Name)
+	assert.Equal(t, nil, report.Results[0].Err)
+	assert.Equal(t, context.DeadlineExceeded, report.Results[1].Err)
+}
+
+// TestMonitorStart 開始時に検査を実行し、停止できる事をテスト
+func TestMonitorStart(t *testing.T) {
+	reporter := &recordReporter{}
+	m := NewMonitor(reporter, time.Hour, time.Second,
+		Dependency{Name: "db", Critical: true, Check: func(ctx context.Context) error { return errUnreachable }},
+	)
+	m.Start()
+	m.Stop()
+	assert.Equal(t, 1, len(reporter.reports))
+	assert.Equal(t, StatusFailed, reporter.reports[0].Status)
+}
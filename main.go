@@ -1,16 +1,169 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/yzmw1213/PostService/auth"
+	"github.com/yzmw1213/PostService/aws"
+	"github.com/yzmw1213/PostService/config"
 	"github.com/yzmw1213/PostService/db"
 	"github.com/yzmw1213/PostService/grpc"
+	"github.com/yzmw1213/PostService/healthcheck"
+	"github.com/yzmw1213/PostService/scheduler"
+	"github.com/yzmw1213/PostService/search"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/usecase/repository"
+	"github.com/yzmw1213/PostService/userdirectory"
 )
 
 func main() {
-	start()
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := cfg.DB.Validate(); err != nil {
+			log.Fatal(err)
+		}
+		migrate(cfg.DB, args[1:])
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+	start(cfg)
 }
 
-func start() {
-	db.Init()
-	grpc.NewPostGrpcServer()
+// migrate マイグレーションを実行する
+// migrate up: 未適用のマイグレーションを全て適用する
+// migrate down: 最後に適用したマイグレーションを1件取り消す
+// migrate status: マイグレーションの適用状況を表示する
+func migrate(cfg config.DB, args []string) {
+	db.Open(cfg)
 	defer db.Close()
+	ctx := context.Background()
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		if err := db.MigrateUp(ctx); err != nil {
+			log.Fatalf("migrate up: %v", err)
+		}
+	case "down":
+		if err := db.MigrateDown(ctx); err != nil {
+			log.Fatalf("migrate down: %v", err)
+		}
+	case "status":
+		statuses, err := db.MigrationStatuses(ctx)
+		if err != nil {
+			log.Fatalf("migrate status: %v", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		log.Fatalf("unknown migrate command: %s (up|down|status)", command)
+	}
+}
+
+func start(cfg *config.Config) {
+	// リクエストのトークンを検証する鍵を読み込む
+	verifier, err := auth.NewVerifier(cfg.Auth)
+	if err != nil {
+		log.Fatalf("could not load auth keys: %v", err)
+	}
+
+	db.Init(cfg.DB)
+
+	storage, err := aws.NewS3Storage(cfg.Storage)
+	if err != nil {
+		log.Fatalf("could not create storage: %v", err)
+	}
+
+	// UserServiceへの接続は全てのリクエストで共有する
+	users, err := userdirectory.Dial(cfg.User.URL, cfg.User.CacheTTL)
+	if err != nil {
+		log.Fatalf("could not connect: %v", err)
+	}
+
+	postUsecase := &interactor.PostInteractor{
+		SearchIndex: newSearchIndex(),
+		Users:       users,
+	}
+	// SQLiteにはFULLTEXTインデックスがないため、プロセス内のインデックスを登録済みの投稿から作り直す
+	if db.IsSQLite() {
+		if err := postUsecase.RebuildSearchIndex(context.Background()); err != nil {
+			log.Fatalf("could not rebuild search index: %v", err)
+		}
+	}
+
+	// 予約投稿の公開処理を開始
+	publisher := scheduler.NewPublishScheduler(postUsecase, cfg.Scheduler.PublishInterval)
+	publisher.Start()
+
+	// ゴミ箱の完全削除処理を開始
+	retention := scheduler.NewRetentionScheduler(postUsecase, cfg.Scheduler.TrashRetentionDays)
+	retention.Start()
+
+	server, err := grpc.NewPostGrpcServer(cfg.Server, verifier, postUsecase, &interactor.TagInteractor{}, storage)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	// 依存先の接続を定期的に検査し、ヘルスチェックに反映する
+	// DBに接続できない場合はリクエストを処理できないため、サービス全体をNOT_SERVINGにする
+	monitor := healthcheck.NewMonitor(server, cfg.Health.CheckInterval, cfg.Health.CheckTimeout,
+		healthcheck.Dependency{Name: "db", Critical: true, Check: db.Ping},
+		healthcheck.Dependency{Name: "storage", Check: storage.Ping},
+		healthcheck.Dependency{Name: "user", Check: users.Ping},
+	)
+	monitor.Start()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve()
+	}()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+
+	// Block until a signal is received
+	select {
+	case sig := <-ch:
+		log.Printf("received %v\n", sig)
+	case err := <-serveErr:
+		log.Printf("failed to serve: %v\n", err)
+	}
+
+	// 処理中のリクエストが使うため、リクエストの完了後にワーカー、接続の順に停止する
+	server.Shutdown()
+	monitor.Stop()
+	publisher.Stop()
+	retention.Stop()
+	if err := users.Close(); err != nil {
+		log.Printf("could not close user connection: %v\n", err)
+	}
+	db.Close()
+	log.Println("End of Program")
+}
+
+// newSearchIndex 接続先のDBに応じた全文検索インデックスを返す
+func newSearchIndex() repository.SearchIndex {
+	if db.IsSQLite() {
+		return search.NewMemoryIndex()
+	}
+	return search.NewMySQLIndex()
 }
@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// NewPublishScheduler 公開予定日時を過ぎた下書きをintervalごとに公開するSchedulerを生成する
+func NewPublishScheduler(postUsecase repository.PostRepository, interval time.Duration) *Scheduler {
+	return New("publish", interval, func(ctx context.Context, now time.Time) error {
+		count, err := postUsecase.PublishScheduledPosts(ctx, now)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			log.Printf("published %d scheduled posts\n", count)
+		}
+		return nil
+	})
+}
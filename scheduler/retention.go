@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// retentionInterval ゴミ箱の完全削除処理を実行する間隔
+const retentionInterval = time.Hour
+
+// NewRetentionScheduler 保持日数daysを過ぎたゴミ箱の投稿、コメントを完全に削除するSchedulerを生成する
+func NewRetentionScheduler(postUsecase repository.PostRepository, days int) *Scheduler {
+	return New("retention", retentionInterval, func(ctx context.Context, now time.Time) error {
+		count, err := postUsecase.PurgeTrashed(ctx, now.AddDate(0, 0, -days))
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			log.Printf("purged %d trashed posts\n", count)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job 定期実行する処理
+// ctxはSchedulerの停止時にキャンセルされる
+type Job func(ctx context.Context, now time.Time) error
+
+// Scheduler 一定間隔でJobを実行するバックグラウンドワーカー
+type Scheduler struct {
+	name     string
+	interval time.Duration
+	job      Job
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// New Schedulerを生成する
+func New(name string, interval time.Duration, job Job) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		name:     name,
+		interval: interval,
+		job:      job,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start Jobの定期実行を開始する
+func (s *Scheduler) Start() {
+	go s.run()
+	log.Printf("%s scheduler has started (interval: %v)\n", s.name, s.interval)
+}
+
+// Stop Jobの定期実行を停止し、実行中のJobの終了を待つ
+// 実行中のJobのクエリはキャンセルされる
+func (s *Scheduler) Stop() {
+	s.cancel()
+	<-s.done
+	log.Printf("%s scheduler has stopped\n", s.name)
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.job(s.ctx, now); err != nil {
+				log.Printf("%s scheduler job failed: %v\n", s.name, err)
+			}
+		}
+	}
+}
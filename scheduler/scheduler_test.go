@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+)
+
+// TestSchedulerRunsJob 停止するまでJobが繰り返し実行される事をテスト
+func TestSchedulerRunsJob(t *testing.T) {
+	var count int32
+	s := New("test", 10*time.Millisecond, func(ctx context.Context, now time.Time) error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	})
+	s.Start()
+	time.Sleep(55 * time.Millisecond)
+	s.Stop()
+
+	stopped := atomic.LoadInt32(&count)
+	assert.NotEqual(t, int32(0), stopped)
+
+	// 停止後はJobが実行されない
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&count))
+}
+
+// TestSchedulerStopTwice Stopを複数回呼んでもpanicしない事をテスト
+func TestSchedulerStopTwice(t *testing.T) {
+	s := New("test", time.Hour, func(ctx context.Context, now time.Time) error { return nil })
+	s.Start()
+	s.Stop()
+	s.Stop()
+}
+
+// TestSchedulerStopCancelsJob 停止時に実行中のJobのcontextがキャンセルされる事をテスト
+func TestSchedulerStopCancelsJob(t *testing.T) {
+	started := make(chan struct{})
+	var cancelled int32
+	s := New("test", 10*time.Millisecond, func(ctx context.Context, now time.Time) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-ctx.Done()
+		atomic.StoreInt32(&cancelled, 1)
+		return ctx.Err()
+	})
+	s.Start()
+	<-started
+	s.Stop()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cancelled))
+}
@@ -0,0 +1,171 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// titleWeight 件名に含まれるトークンの重み
+const titleWeight = 2.0
+
+// MemoryIndex プロセス内に保持するn-gram転置インデックス
+// 永続化しないため、テストやローカル環境での利用を想定する
+type MemoryIndex struct {
+	mu sync.RWMutex
+	// トークンごとの投稿ID、出現回数(重み付き)
+	postings map[string]map[uint32]float64
+	docs     map[uint32]*memoryDocument
+}
+
+// memoryDocument インデックスに登録された投稿
+type memoryDocument struct {
+	createUserID uint32
+	tagIDs       map[uint32]bool
+	tokens       map[string]float64
+}
+
+var _ repository.SearchIndex = (*MemoryIndex)(nil)
+
+// NewMemoryIndex 空のMemoryIndexを生成する
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		postings: map[string]map[uint32]float64{},
+		docs:     map[uint32]*memoryDocument{},
+	}
+}
+
+// Index 投稿をインデックスに登録する。登録済みの場合は置き換える
+func (i *MemoryIndex) Index(ctx context.Context, post model.Post, tagIDs []uint32) error {
+	doc := &memoryDocument{
+		createUserID: post.CreateUserID,
+		tagIDs:       map[uint32]bool{},
+		tokens:       map[string]float64{},
+	}
+	for _, tagID := range tagIDs {
+		doc.tagIDs[tagID] = true
+	}
+	for _, token := range tokenize(post.Title) {
+		doc.tokens[token] += titleWeight
+	}
+	for _, token := range tokenize(post.Content) {
+		doc.tokens[token]++
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(post.ID)
+	i.docs[post.ID] = doc
+	for token, weight := range doc.tokens {
+		if i.postings[token] == nil {
+			i.postings[token] = map[uint32]float64{}
+		}
+		i.postings[token][post.ID] = weight
+	}
+	return nil
+}
+
+// Remove 投稿をインデックスから削除する
+func (i *MemoryIndex) Remove(ctx context.Context, postID uint32) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(postID)
+	return nil
+}
+
+func (i *MemoryIndex) remove(postID uint32) {
+	doc, ok := i.docs[postID]
+	if !ok {
+		return
+	}
+	for token := range doc.tokens {
+		delete(i.postings[token], postID)
+		if len(i.postings[token]) == 0 {
+			delete(i.postings, token)
+		}
+	}
+	delete(i.docs, postID)
+}
+
+// Search キーワードの全てのトークンを含む投稿を、TF-IDFの合計が大きい順に最大limit件返す
+func (i *MemoryIndex) Search(ctx context.Context, query model.PostSearchQuery, limit int) ([]model.SearchHit, error) {
+	tokens := uniqueTokens(strings.Fields(query.Keyword))
+	if len(tokens) == 0 {
+		return []model.SearchHit{}, nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	scores := map[uint32]float64{}
+	for n, token := range tokens {
+		postings := i.postings[token]
+		idf := math.Log(1 + float64(len(i.docs))/float64(len(postings)+1))
+		next := map[uint32]float64{}
+		for postID, weight := range postings {
+			// 全てのトークンを含む投稿のみ残す
+			if n > 0 {
+				if _, ok := scores[postID]; !ok {
+					continue
+				}
+			}
+			next[postID] = scores[postID] + weight*idf
+		}
+		scores = next
+	}
+
+	hits := []model.SearchHit{}
+	for postID, score := range scores {
+		if i.match(i.docs[postID], query) {
+			hits = append(hits, model.SearchHit{PostID: postID, Score: score})
+		}
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].PostID > hits[b].PostID
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// match 投稿がタグ、作成ユーザーの絞り込み条件を満たすか判定する
+func (i *MemoryIndex) match(doc *memoryDocument, query model.PostSearchQuery) bool {
+	for _, tagID := range query.TagIDs {
+		if !doc.tagIDs[tagID] {
+			return false
+		}
+	}
+	if len(query.CreateUserIDs) == 0 {
+		return true
+	}
+	for _, userID := range query.CreateUserIDs {
+		if doc.createUserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueTokens 検索語をn-gramに分割し、重複を除いて返す
+func uniqueTokens(words []string) []string {
+	var tokens []string
+	seen := map[string]bool{}
+	for _, word := range words {
+		for _, token := range tokenize(word) {
+			if !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}
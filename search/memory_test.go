@@ -0,0 +1,75 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+func makeTestIndex() *MemoryIndex {
+	index := NewMemoryIndex()
+	index.Index(context.Background(), model.Post{ID: 1, Title: "東京のラーメン", Content: "醤油ラーメンが美味しい店", CreateUserID: 1}, []uint32{1})
+	index.Index(context.Background(), model.Post{ID: 2, Title: "京都旅行", Content: "東京から新幹線で京都へ。ラーメンも食べた", CreateUserID: 2}, []uint32{1, 2})
+	index.Index(context.Background(), model.Post{ID: 3, Title: "Go言語入門", Content: "GoでgRPCサーバーを書く", CreateUserID: 1}, []uint32{3})
+	return index
+}
+
+func hitIDs(hits []model.SearchHit) []uint32 {
+	ids := []uint32{}
+	for _, hit := range hits {
+		ids = append(ids, hit.PostID)
+	}
+	return ids
+}
+
+// TestMemoryIndexSearch キーワードを含む投稿が関連度順に返る事をテスト
+func TestMemoryIndexSearch(t *testing.T) {
+	index := makeTestIndex()
+
+	hits, err := index.Search(context.Background(), model.PostSearchQuery{Keyword: "ラーメン"}, 10)
+	assert.Equal(t, nil, err)
+	// 件名に含む投稿が上位
+	assert.Equal(t, []uint32{1, 2}, hitIDs(hits))
+
+	// 全ての語を含む投稿のみ
+	hits, _ = index.Search(context.Background(), model.PostSearchQuery{Keyword: "東京 新幹線"}, 10)
+	assert.Equal(t, []uint32{2}, hitIDs(hits))
+
+	// 全角英字でも一致する
+	hits, _ = index.Search(context.Background(), model.PostSearchQuery{Keyword: "ｇＲＰＣ"}, 10)
+	assert.Equal(t, []uint32{3}, hitIDs(hits))
+
+	hits, _ = index.Search(context.Background(), model.PostSearchQuery{Keyword: "大阪"}, 10)
+	assert.Equal(t, 0, len(hits))
+}
+
+// TestMemoryIndexSearchFilter タグ、作成ユーザーでの絞り込みをテスト
+func TestMemoryIndexSearchFilter(t *testing.T) {
+	index := makeTestIndex()
+
+	hits, _ := index.Search(context.Background(), model.PostSearchQuery{Keyword: "ラーメン", TagIDs: []uint32{1, 2}}, 10)
+	assert.Equal(t, []uint32{2}, hitIDs(hits))
+
+	hits, _ = index.Search(context.Background(), model.PostSearchQuery{Keyword: "ラーメン", CreateUserIDs: []uint32{1, 3}}, 10)
+	assert.Equal(t, []uint32{1}, hitIDs(hits))
+
+	hits, _ = index.Search(context.Background(), model.PostSearchQuery{Keyword: "ラーメン"}, 1)
+	assert.Equal(t, 1, len(hits))
+}
+
+// TestMemoryIndexUpdate 再登録、削除がインデックスに反映される事をテスト
+func TestMemoryIndexUpdate(t *testing.T) {
+	index := makeTestIndex()
+
+	index.Index(context.Background(), model.Post{ID: 1, Title: "大阪のお好み焼き", Content: "大阪で食べた", CreateUserID: 1}, nil)
+	hits, _ := index.Search(context.Background(), model.PostSearchQuery{Keyword: "ラーメン"}, 10)
+	assert.Equal(t, []uint32{2}, hitIDs(hits))
+	hits, _ = index.Search(context.Background(), model.PostSearchQuery{Keyword: "大阪"}, 10)
+	assert.Equal(t, []uint32{1}, hitIDs(hits))
+
+	index.Remove(context.Background(), 1)
+	hits, _ = index.Search(context.Background(), model.PostSearchQuery{Keyword: "大阪"}, 10)
+	assert.Equal(t, 0, len(hits))
+}
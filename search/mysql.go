@@ -0,0 +1,69 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// MySQLIndex MySQLのFULLTEXTインデックス(ngramパーサー)を用いた全文検索
+// インデックスは投稿の登録、更新に合わせてMySQLが更新するため、Index、Removeでは何もしない
+type MySQLIndex struct{}
+
+var _ repository.SearchIndex = (*MySQLIndex)(nil)
+
+// NewMySQLIndex MySQLIndexを生成する
+func NewMySQLIndex() *MySQLIndex {
+	return &MySQLIndex{}
+}
+
+// Index MySQLが更新するため何もしない
+func (i *MySQLIndex) Index(ctx context.Context, post model.Post, tagIDs []uint32) error {
+	return nil
+}
+
+// Remove MySQLが更新するため何もしない
+func (i *MySQLIndex) Remove(ctx context.Context, postID uint32) error {
+	return nil
+}
+
+// Search キーワードの全ての語を含む投稿を、関連度が大きい順に最大limit件返す
+func (i *MySQLIndex) Search(ctx context.Context, query model.PostSearchQuery, limit int) ([]model.SearchHit, error) {
+	hits := []model.SearchHit{}
+	against := booleanModeQuery(query.Keyword)
+	if against == "" {
+		return hits, nil
+	}
+
+	DB := db.Conn(ctx)
+	match := "MATCH(posts.title, posts.content) AGAINST(? IN BOOLEAN MODE)"
+	q := DB.Table(db.PostTableName).
+		Select("posts.id AS post_id, "+match+" AS score", against).
+		Where(match, against)
+	if len(query.CreateUserIDs) > 0 {
+		q = q.Where("posts.create_user_id IN (?)", query.CreateUserIDs)
+	}
+	for _, tagID := range query.TagIDs {
+		q = q.Where("EXISTS (SELECT 1 FROM post_tags WHERE post_tags.post_id = posts.id AND post_tags.tag_id = ?)", tagID)
+	}
+	err := q.Order("score desc").Order("posts.id desc").Limit(limit).Scan(&hits).Error
+	return hits, err
+}
+
+// booleanModeQuery 空白区切りの検索語を、全ての語を必須とするBOOLEAN MODEの検索式に変換する
+// 各語はフレーズ検索とし、ngramに分割した並びのまま一致させる
+func booleanModeQuery(keyword string) string {
+	var terms []string
+	for _, word := range strings.Fields(normalize(keyword)) {
+		word = strings.Trim(strings.Replace(word, `"`, "", -1), "+-<>()~*@")
+		if word == "" {
+			continue
+		}
+		terms = append(terms, fmt.Sprintf(`+"%s"`, word))
+	}
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,14 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+// TestBooleanModeQuery 検索語が全て必須のフレーズ検索式に変換される事をテスト
+func TestBooleanModeQuery(t *testing.T) {
+	assert.Equal(t, `+"東京" +"ラーメン"`, booleanModeQuery("東京　ラーメン"))
+	assert.Equal(t, `+"go"`, booleanModeQuery(`-"Go"*`))
+	assert.Equal(t, "", booleanModeQuery("  "))
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ngramSize n-gramの文字数
+// MySQLのngramパーサーのデフォルト(ngram_token_size=2)に合わせる
+const ngramSize = 2
+
+// normalize 全角英数、半角カナ等の表記揺れを吸収し、小文字に揃える
+func normalize(text string) string {
+	return strings.ToLower(norm.NFKC.String(text))
+}
+
+// tokenize 文字列をn-gramに分割する
+// 日本語は単語の区切りがないため、空白や記号で区切った語をさらに文字単位のn-gramに分割する
+func tokenize(text string) []string {
+	var tokens []string
+	for _, word := range splitWords(text) {
+		tokens = append(tokens, ngrams(word)...)
+	}
+	return tokens
+}
+
+// splitWords 正規化した文字列を文字、数字の連続ごとに分割する
+func splitWords(text string) [][]rune {
+	var words [][]rune
+	var word []rune
+	for _, r := range normalize(text) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || r == 'ー' {
+			word = append(word, r)
+			continue
+		}
+		if len(word) > 0 {
+			words = append(words, word)
+			word = nil
+		}
+	}
+	if len(word) > 0 {
+		words = append(words, word)
+	}
+	return words
+}
+
+// ngrams 語をn-gramに分割する。n文字未満の語はそのまま1トークンとする
+func ngrams(word []rune) []string {
+	if len(word) < ngramSize {
+		return []string{string(word)}
+	}
+	tokens := make([]string, 0, len(word)-ngramSize+1)
+	for i := 0; i+ngramSize <= len(word); i++ {
+		tokens = append(tokens, string(word[i:i+ngramSize]))
+	}
+	return tokens
+}
@@ -0,0 +1,22 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+// TestTokenize 日本語、英数字がn-gramに分割される事をテスト
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"東京", "京都"}, tokenize("東京都"))
+	assert.Equal(t, []string{"go", "言語"}, tokenize("Go 言語"))
+	assert.Equal(t, []string{"a"}, tokenize("a"))
+	assert.Equal(t, 0, len(tokenize("、。 !?")))
+}
+
+// TestNormalize 全角英数、半角カナの表記揺れが吸収される事をテスト
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "golang", normalize("ＧｏＬａｎｇ"))
+	assert.Equal(t, "カタカナ", normalize("ｶﾀｶﾅ"))
+	assert.Equal(t, tokenize("ラーメン"), tokenize("ﾗｰﾒﾝ"))
+}
@@ -0,0 +1,209 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+	"github.com/mattn/go-sqlite3"
+)
+
+// ErrorKind ドメインエラーの種類
+type ErrorKind int
+
+const (
+	// KindInternal 想定外のエラー
+	KindInternal ErrorKind = iota
+	// KindNotFound 指定したリソースが存在しない
+	KindNotFound
+	// KindAlreadyExists 登録済みのリソースを登録しようとした
+	KindAlreadyExists
+	// KindPermissionDenied リソースを操作する権限がない
+	KindPermissionDenied
+	// KindConflict 更新の前提条件が他の更新により変わっていた
+	KindConflict
+	// KindUnavailable DB、ストレージなどの依存先に接続できない
+	KindUnavailable
+	// KindInvalidArgument 指定した条件が不正
+	KindInvalidArgument
+)
+
+const (
+	// ResourcePost 投稿のリソース種別
+	ResourcePost string = "posts"
+	// ResourceComment コメントのリソース種別
+	ResourceComment string = "comments"
+	// ResourceTag タグのリソース種別
+	ResourceTag string = "tags"
+	// ResourcePostRevision 投稿の更新履歴のリソース種別
+	ResourcePostRevision string = "post_revisions"
+	// ResourcePostLike 投稿のお気に入りのリソース種別
+	ResourcePostLike string = "post_like_users"
+	// ResourceStorage 画像の保存先のリソース種別
+	ResourceStorage string = "storage"
+)
+
+// defaultRetryDelay 依存先に接続できない時に、再試行までに待つ時間の目安
+const defaultRetryDelay = time.Second
+
+// Error 種類と対象のリソースを持つドメインエラー
+// Errは原因の調査用にログにのみ出力し、クライアントには返さない
+type Error struct {
+	Kind ErrorKind
+	// Resource 対象のリソース種別(テーブル名)
+	Resource string
+	// ID 対象のリソースのID。特定できない場合は0
+	ID uint32
+	// RetryDelay KindUnavailableの場合に、再試行までに待つ時間の目安
+	RetryDelay time.Duration
+	Err        error
+}
+
+// Error ログに出力するエラーの内容を返す
+func (e *Error) Error() string {
+	target := e.Resource
+	if e.ID != 0 {
+		target = fmt.Sprintf("%s %d", e.Resource, e.ID)
+	}
+	if e.Err == nil {
+		return fmt.Sprintf("%s: kind %d", target, e.Kind)
+	}
+	return fmt.Sprintf("%s: %v", target, e.Err)
+}
+
+// Unwrap 原因のエラーを返す
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewNotFoundError 指定したリソースが存在しない時のエラーを返す
+func NewNotFoundError(resource string, id uint32) error {
+	return &Error{Kind: KindNotFound, Resource: resource, ID: id, Err: gorm.ErrRecordNotFound}
+}
+
+// wrapNotFound レコードが存在しない時のエラーを、対象のリソースを持つエラーにする
+func wrapNotFound(err error, resource string, id uint32) error {
+	if gorm.IsRecordNotFoundError(err) {
+		return NewNotFoundError(resource, id)
+	}
+	return err
+}
+
+// NewVersionConflictError 版数が一致せず更新できなかった時のエラーを返す
+func NewVersionConflictError(resource string, id uint32) error {
+	return &Error{Kind: KindConflict, Resource: resource, ID: id, Err: ErrVersionConflict}
+}
+
+// NewAlreadyLikedError 既にお気に入りした投稿をお気に入りしようとした時のエラーを返す
+func NewAlreadyLikedError(postID uint32) error {
+	return &Error{Kind: KindAlreadyExists, Resource: ResourcePostLike, ID: postID, Err: ErrAlreadyLiked}
+}
+
+// NewRevisionNotFoundError 指定した投稿の更新履歴が存在しない時のエラーを返す
+func NewRevisionNotFoundError(postID uint32) error {
+	return &Error{Kind: KindNotFound, Resource: ResourcePostRevision, ID: postID, Err: ErrPostRevisionNotExists}
+}
+
+// NewUnavailableError 依存先に接続できない時のエラーを返す
+func NewUnavailableError(resource string, err error) error {
+	return &Error{Kind: KindUnavailable, Resource: resource, RetryDelay: defaultRetryDelay, Err: err}
+}
+
+// NewPermissionDeniedError リソースを操作する権限がない時のエラーを返す
+func NewPermissionDeniedError(resource string, id uint32) error {
+	return &Error{Kind: KindPermissionDenied, Resource: resource, ID: id}
+}
+
+// invalidArgumentErrors 指定した条件が不正な時のエラーの一覧
+var invalidArgumentErrors = []error{
+	ErrInvalidListCondition,
+	ErrInvalidTagMatch,
+	ErrInvalidImageFilter,
+	ErrInvalidCreatedAt,
+	ErrInvalidCreatedAtRange,
+	ErrInvalidCommentCountRange,
+	ErrInvalidPageToken,
+	ErrInvalidPostSort,
+	ErrInvalidPostStatus,
+	ErrInvalidPostStatusTransition,
+	ErrInvalidPublishAt,
+	ErrVersionRequired,
+	ErrEmptySearchKeyword,
+}
+
+// KindOf エラーの種類を返す
+// Errorで包まれていないエラーは、原因のエラーから種類を判定する
+func KindOf(err error) ErrorKind {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+	switch {
+	case gorm.IsRecordNotFoundError(err), errors.Is(err, ErrPostRevisionNotExists):
+		return KindNotFound
+	case errors.Is(err, ErrAlreadyLiked), isDuplicateEntry(err):
+		return KindAlreadyExists
+	case errors.Is(err, ErrVersionConflict):
+		return KindConflict
+	case isUnavailable(err):
+		return KindUnavailable
+	}
+	for _, invalid := range invalidArgumentErrors {
+		if errors.Is(err, invalid) {
+			return KindInvalidArgument
+		}
+	}
+	return KindInternal
+}
+
+// ErrDuplicateEntry 登録済みのIDで登録しようとした時のエラー
+// DB以外のリポジトリの実装は、一意制約、主キー制約の違反の代わりにこのエラーを返す
+var ErrDuplicateEntry = errors.New("duplicate entry")
+
+// mysqlErDupEntry MySQLの一意制約、主キー制約違反のエラー番号
+const mysqlErDupEntry = 1062
+
+// isDuplicateEntry 一意制約、主キー制約に違反して登録できなかった時のエラーか判定する
+func isDuplicateEntry(err error) bool {
+	if errors.Is(err, ErrDuplicateEntry) {
+		return true
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlErDupEntry
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey || sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+	return false
+}
+
+// isUnavailable DBに接続できない時のエラーか判定する
+func isUnavailable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, mysql.ErrInvalidConn) {
+		return true
+	}
+	// リクエストのキャンセル、タイムアウトは接続の問題として扱わない
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code {
+		case sqlite3.ErrBusy, sqlite3.ErrLocked, sqlite3.ErrCantOpen:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package interactor
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+	"github.com/mattn/go-sqlite3"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// TestKindOf エラーの種類の判定をテスト
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{"not found", NewNotFoundError(ResourcePost, 1), KindNotFound},
+		{"raw record not found", gorm.ErrRecordNotFound, KindNotFound},
+		{"wrapped version conflict", pkgerrors.Wrap(NewVersionConflictError(ResourcePost, 1), "update post"), KindConflict},
+		{"raw version conflict", ErrVersionConflict, KindConflict},
+		{"already liked", NewAlreadyLikedError(1), KindAlreadyExists},
+		{"mysql duplicate entry", pkgerrors.Wrap(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, "like post"), KindAlreadyExists},
+		{"mysql other error", &mysql.MySQLError{Number: 1064, Message: "syntax error"}, KindInternal},
+		{"sqlite primary key", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintPrimaryKey}, KindAlreadyExists},
+		{"sqlite unique", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}, KindAlreadyExists},
+		{"sqlite foreign key", sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintForeignKey}, KindInternal},
+		{"permission denied", NewPermissionDeniedError(ResourceTag, 1), KindPermissionDenied},
+		{"storage unavailable", NewUnavailableError(ResourceStorage, errors.New("timeout")), KindUnavailable},
+		{"bad connection", pkgerrors.Wrap(driver.ErrBadConn, "list posts"), KindUnavailable},
+		{"network error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, KindUnavailable},
+		{"invalid condition", ErrInvalidListCondition, KindInvalidArgument},
+		{"cancelled", context.Canceled, KindInternal},
+		{"unknown", errors.New("syntax error"), KindInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, KindOf(tt.err))
+		})
+	}
+}
+
+// TestErrorUnwrap 原因のエラーを判定できる事をテスト
+func TestErrorUnwrap(t *testing.T) {
+	err := pkgerrors.Wrap(NewNotFoundError(ResourceComment, 3), "update comment")
+	assert.Equal(t, true, errors.Is(err, gorm.ErrRecordNotFound))
+
+	var domainErr *Error
+	assert.Equal(t, true, errors.As(err, &domainErr))
+	assert.Equal(t, ResourceComment, domainErr.Resource)
+	assert.Equal(t, uint32(3), domainErr.ID)
+}
@@ -0,0 +1,4 @@
+package interactor
+
+// RepositoryConformance 外部パッケージのテストから、リポジトリの共通仕様をテストする
+var RepositoryConformance = testRepositoryConformance
@@ -0,0 +1,36 @@
+package interactor
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yzmw1213/PostService/config"
+	"github.com/yzmw1213/PostService/db"
+)
+
+// TestMain DB_DRIVERが未指定の場合は一時ファイルのSQLiteを用いてテストする
+// MySQLでテストする場合はDB_DRIVER=mysqlと接続先を指定する
+func TestMain(m *testing.M) {
+	cfg, _, err := config.Load(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var dir string
+	if os.Getenv("DB_DRIVER") == "" {
+		dir, err = ioutil.TempDir("", "post-service-test")
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.DB = config.DB{Driver: db.SQLiteDriver, Name: filepath.Join(dir, "test.db")}
+	}
+	db.Init(cfg.DB)
+	code := m.Run()
+	db.Close()
+	if dir != "" {
+		os.RemoveAll(dir)
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,1082 @@
+package memory
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// PostRepository プロセス内に投稿を保持するPostRepositoryの実装
+// 永続化しないため、テストでの利用を想定する
+// 検証、ステータス遷移、版数、ページトークンはPostInteractorと同じ規則に従う
+type PostRepository struct {
+	// 全文検索インデックス
+	SearchIndex repository.SearchIndex
+	// ユーザー情報の参照先(未設定の場合は全てのユーザーをUnknownUserNameとして扱う)
+	Users repository.UserDirectory
+
+	// 投稿に紐付けるタグの参照先
+	tags *TagRepository
+
+	mu             sync.RWMutex
+	posts          map[uint32]model.Post
+	postTags       []model.PostTag
+	postLikeUsers  []model.PostLikeUser
+	comments       map[uint32]model.Comment
+	revisions      []model.PostRevision
+	revisionTags   []model.PostRevisionTag
+	lastPostID     uint32
+	lastCommentID  uint32
+	lastRevisionID uint32
+}
+
+var _ repository.PostRepository = (*PostRepository)(nil)
+
+// NewPostRepository 空のPostRepositoryを生成する
+// 投稿にはtagsに登録された有効なタグのみを紐付けて返す。tagsがnilの場合はタグを紐付けない
+// タグを削除した場合は、投稿への紐付けも削除する
+func NewPostRepository(tags *TagRepository) *PostRepository {
+	r := &PostRepository{
+		tags:     tags,
+		posts:    map[uint32]model.Post{},
+		comments: map[uint32]model.Comment{},
+	}
+	if tags != nil {
+		tags.setPosts(r)
+	}
+	return r
+}
+
+// Create 投稿1件を作成
+func (r *PostRepository) Create(ctx context.Context, postData *model.JoinPost) (*model.JoinPost, error) {
+	post := postData.Post
+
+	// Post構造体のバリデーション
+	if err := model.Validate(post); err != nil {
+		return postData, err
+	}
+
+	// 作成時のステータスを決定
+	status, err := interactor.InitialPostStatus(post.Status)
+	if err != nil {
+		return postData, err
+	}
+	now := time.Now()
+	post.Status = status
+	post.Version = 1
+	// 公開予定日時が未来の場合は予約投稿として下書きで登録する
+	if interactor.IsScheduled(post, now) {
+		post.Status = interactor.DraftPostStatus
+	}
+	if err := ctx.Err(); err != nil {
+		return postData, err
+	}
+
+	r.mu.Lock()
+	if post.ID == 0 {
+		post.ID = r.lastPostID + 1
+	}
+	if _, ok := r.posts[post.ID]; ok {
+		r.mu.Unlock()
+		return postData, interactor.ErrDuplicateEntry
+	}
+	if post.ID > r.lastPostID {
+		r.lastPostID = post.ID
+	}
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = now
+	}
+	if post.UpdatedAt.IsZero() {
+		post.UpdatedAt = now
+	}
+	r.posts[post.ID] = *post
+	for _, tag := range postData.PostTags {
+		r.postTags = append(r.postTags, model.PostTag{PostID: post.ID, TagID: tag.TagID})
+	}
+	// 作成時の内容を最初の版として登録
+	r.recordRevision(post.ID, post.CreateUserID, now)
+	r.mu.Unlock()
+
+	r.indexPost(ctx, post.ID)
+	return postData, nil
+}
+
+// DeleteByID 指定されたIDに対する投稿1件をゴミ箱に移動する
+// タグ、お気に入り、コメントの紐付けは復元できるよう完全削除まで残す
+func (r *PostRepository) DeleteByID(ctx context.Context, id uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.trashPosts(func(post model.Post) bool { return post.ID == id })
+	r.mu.Unlock()
+
+	r.unindexPosts(ctx, id)
+	return nil
+}
+
+// GetTrashedByID ゴミ箱に移動した投稿を1件取得する
+func (r *PostRepository) GetTrashedByID(ctx context.Context, id uint32) (model.Post, error) {
+	if err := ctx.Err(); err != nil {
+		return model.Post{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	post, ok := r.posts[id]
+	if !ok || post.DeletedAt == nil {
+		return model.Post{}, interactor.NewNotFoundError(interactor.ResourcePost, id)
+	}
+	return post, nil
+}
+
+// Restore ゴミ箱に移動した投稿を元に戻す
+func (r *PostRepository) Restore(ctx context.Context, id uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	post, ok := r.posts[id]
+	if !ok || post.DeletedAt == nil {
+		r.mu.Unlock()
+		return interactor.NewNotFoundError(interactor.ResourcePost, id)
+	}
+	post.DeletedAt = nil
+	r.posts[id] = post
+	r.mu.Unlock()
+
+	r.indexPost(ctx, id)
+	return nil
+}
+
+// ListTrashed ゴミ箱に移動したユーザーの投稿を1ページ分取得し、次ページのトークンと共に返す
+func (r *PostRepository) ListTrashed(ctx context.Context, userID uint32, page model.Pagination) ([]model.JoinPost, string, error) {
+	cursor, err := interactor.DecodePostCursor(page.PageToken)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	size := interactor.NormalizePageSize(page.PageSize)
+	if err := ctx.Err(); err != nil {
+		return []model.JoinPost{}, "", err
+	}
+
+	r.mu.RLock()
+	var rows []interactor.SortedPost
+	for _, post := range r.posts {
+		if post.DeletedAt != nil && post.CreateUserID == userID {
+			rows = append(rows, interactor.SortedPost{Post: post})
+		}
+	}
+	r.mu.RUnlock()
+
+	rows = pageSortedPosts(rows, model.PostSortNewest, cursor, size+1)
+	posts, nextPageToken := interactor.SplitPage(unwrapSortedPosts(rows), size)
+
+	joinPosts, err := r.createJoinPosts(ctx, posts)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	return joinPosts, nextPageToken, nil
+}
+
+// Purge ゴミ箱に移動した投稿を、紐付け情報と共に完全に削除する
+func (r *PostRepository) Purge(ctx context.Context, id uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	post, ok := r.posts[id]
+	if !ok || post.DeletedAt == nil {
+		r.mu.Unlock()
+		return interactor.NewNotFoundError(interactor.ResourcePost, id)
+	}
+	r.purgePosts([]uint32{id})
+	r.mu.Unlock()
+
+	r.unindexPosts(ctx, id)
+	return nil
+}
+
+// PurgeTrashed before より前にゴミ箱に移動した投稿、コメントを完全に削除し、削除した投稿件数を返す
+func (r *PostRepository) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	var ids []uint32
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	for id, post := range r.posts {
+		if post.DeletedAt != nil && post.DeletedAt.Before(before) {
+			ids = append(ids, id)
+		}
+	}
+	r.purgePosts(ids)
+	for id, comment := range r.comments {
+		if comment.DeletedAt != nil && comment.DeletedAt.Before(before) {
+			delete(r.comments, id)
+		}
+	}
+	r.mu.Unlock()
+
+	r.unindexPosts(ctx, ids...)
+	return int64(len(ids)), nil
+}
+
+// List 条件に応じて投稿を1ページ分取得し、次ページのトークンと共に返す
+// 公開中以外の投稿は閲覧ユーザーが投稿者本人の場合のみ返す
+func (r *PostRepository) List(ctx context.Context, condition model.PostListCondition, page model.Pagination) ([]model.JoinPost, string, error) {
+	cursor, err := interactor.DecodePostCursor(page.PageToken)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	size := interactor.NormalizePageSize(page.PageSize)
+	filter, err := interactor.ListConditionFilter(condition)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	if err := interactor.ValidatePostFilter(filter); err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	if err := interactor.ValidatePostSort(condition.Sort, cursor); err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	if err := ctx.Err(); err != nil {
+		return []model.JoinPost{}, "", err
+	}
+
+	now := time.Now()
+	reference := interactor.SortReferenceTime(cursor)
+	r.mu.RLock()
+	var rows []interactor.SortedPost
+	for _, post := range r.posts {
+		if post.DeletedAt != nil || !interactor.IsVisiblePost(&post, condition.ViewerID, now) {
+			continue
+		}
+		if condition.Status != 0 && post.Status != condition.Status {
+			continue
+		}
+		if !r.matchFilter(post, filter) {
+			continue
+		}
+		rows = append(rows, interactor.SortedPost{Post: post, SortKey: r.sortKey(post, condition.Sort, reference)})
+	}
+	r.mu.RUnlock()
+
+	rows = pageSortedPosts(rows, condition.Sort, cursor, size+1)
+	var nextPageToken string
+	if uint32(len(rows)) > size {
+		rows = rows[:size]
+		last := rows[len(rows)-1]
+		if interactor.IsKeyedSort(condition.Sort) {
+			nextPageToken = interactor.EncodeSortedPostCursor(last.Post, condition.Sort, last.SortKey, reference)
+		} else {
+			nextPageToken = interactor.EncodeSortedPostCursor(last.Post, condition.Sort, 0, time.Time{})
+		}
+	}
+
+	// 取得したpostsに紐付け情報を付与して返す
+	joinPosts, err := r.createJoinPosts(ctx, unwrapSortedPosts(rows))
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	return joinPosts, nextPageToken, nil
+}
+
+// Search キーワードに一致する投稿を関連度順に1ページ分取得し、次ページのトークンと共に返す
+// 閲覧ユーザーが参照できない投稿は結果から除く
+func (r *PostRepository) Search(ctx context.Context, query model.PostSearchQuery, page model.Pagination) ([]model.JoinPost, string, error) {
+	if strings.TrimSpace(query.Keyword) == "" {
+		return []model.JoinPost{}, "", interactor.ErrEmptySearchKeyword
+	}
+	if r.SearchIndex == nil {
+		return []model.JoinPost{}, "", interactor.ErrSearchIndexNotConfigured
+	}
+	offset, err := interactor.DecodeSearchCursor(page.PageToken)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	size := interactor.NormalizePageSize(page.PageSize)
+
+	hits, err := r.SearchIndex.Search(ctx, query, interactor.MaxSearchHits)
+	if err != nil {
+		log.Println("Error occured while searching posts")
+		return []model.JoinPost{}, "", err
+	}
+
+	now := time.Now()
+	posts := []model.Post{}
+	r.mu.RLock()
+	for _, hit := range hits {
+		post, ok := r.posts[hit.PostID]
+		if ok && post.DeletedAt == nil && interactor.IsVisiblePost(&post, query.ViewerID, now) {
+			posts = append(posts, post)
+		}
+	}
+	r.mu.RUnlock()
+
+	posts, nextPageToken := interactor.SplitSearchPage(posts, offset, size)
+	if len(posts) == 0 {
+		return []model.JoinPost{}, "", nil
+	}
+
+	joinPosts, err := r.createJoinPosts(ctx, posts)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	return joinPosts, nextPageToken, nil
+}
+
+// Update 投稿を更新する
+// 値が設定された項目のみ更新する
+func (r *PostRepository) Update(ctx context.Context, postData *model.JoinPost) (*model.JoinPost, error) {
+	post := postData.Post
+
+	// Post構造体のバリデーション
+	if err := model.Validate(post); err != nil {
+		return postData, err
+	}
+
+	// ステータスが指定された場合は遷移可能か判定
+	if post.Status != 0 {
+		current, err := r.GetByID(ctx, post.ID)
+		if err != nil {
+			return postData, err
+		}
+		if err := interactor.CheckPostStatusChange(ctx, current, post.Status); err != nil {
+			return postData, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return postData, err
+	}
+
+	r.mu.Lock()
+	stored, ok := r.posts[post.ID]
+	if !ok || stored.DeletedAt != nil {
+		r.mu.Unlock()
+		return postData, interactor.NewNotFoundError(interactor.ResourcePost, post.ID)
+	}
+	// 更新前の版数が一致する場合のみ更新する
+	if post.Version == 0 {
+		r.mu.Unlock()
+		return postData, interactor.ErrVersionRequired
+	}
+	if stored.Version != post.Version {
+		r.mu.Unlock()
+		return postData, interactor.NewVersionConflictError(interactor.ResourcePost, post.ID)
+	}
+	now := time.Now()
+
+	// 更新履歴がない場合は更新前の内容を残す
+	if !r.hasRevision(post.ID) {
+		r.recordRevision(post.ID, stored.CreateUserID, now)
+	}
+
+	stored.Version++
+	if post.Status != 0 {
+		if interactor.ClearsPublishAt(stored.Status, post) {
+			stored.PublishAt = nil
+		}
+		stored.Status = post.Status
+	}
+	if post.Title != "" {
+		stored.Title = post.Title
+	}
+	if post.Content != "" {
+		stored.Content = post.Content
+	}
+	if post.Image != "" {
+		stored.Image = post.Image
+	}
+	// 作成ユーザーは変更しない
+	if post.UpdateUserID != 0 {
+		stored.UpdateUserID = post.UpdateUserID
+	}
+	if post.PublishAt != nil {
+		stored.PublishAt = post.PublishAt
+	}
+	stored.UpdatedAt = now
+	r.posts[post.ID] = stored
+	post.Version = stored.Version
+	post.UpdatedAt = now
+
+	// 投稿とタグ紐付け情報を置き換える
+	r.postTags = filterPostTags(r.postTags, func(postTag model.PostTag) bool { return postTag.PostID != post.ID })
+	for _, tag := range postData.PostTags {
+		r.postTags = append(r.postTags, model.PostTag{PostID: post.ID, TagID: tag.TagID})
+	}
+	// 更新後の内容を新しい版として登録
+	r.recordRevision(post.ID, post.UpdateUserID, now)
+	r.mu.Unlock()
+
+	r.indexPost(ctx, post.ID)
+	return postData, nil
+}
+
+// ListRevisions 投稿の更新履歴を新しい順に取得する
+func (r *PostRepository) ListRevisions(ctx context.Context, postID uint32) ([]model.JoinPostRevision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	joinRevisions := []model.JoinPostRevision{}
+	for _, revision := range r.revisions {
+		if revision.PostID == postID {
+			joinRevisions = append(joinRevisions, r.joinRevisionTags(revision))
+		}
+	}
+	sort.Slice(joinRevisions, func(a, b int) bool {
+		return joinRevisions[a].Revision.Revision > joinRevisions[b].Revision.Revision
+	})
+	return joinRevisions, nil
+}
+
+// GetRevision 投稿の更新履歴1件と、直前の版との差分を取得する
+func (r *PostRepository) GetRevision(ctx context.Context, postID uint32, revision uint32) (model.JoinPostRevision, model.PostRevisionDiff, error) {
+	if err := ctx.Err(); err != nil {
+		return model.JoinPostRevision{}, model.PostRevisionDiff{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	current, err := r.getRevision(postID, revision)
+	if err != nil {
+		return model.JoinPostRevision{}, model.PostRevisionDiff{}, err
+	}
+	var previous *model.JoinPostRevision
+	if revision > 1 {
+		prev, err := r.getRevision(postID, revision-1)
+		if err != nil {
+			return model.JoinPostRevision{}, model.PostRevisionDiff{}, err
+		}
+		previous = &prev
+	}
+	return current, interactor.DiffRevisions(previous, current), nil
+}
+
+// RevertToRevision 投稿の件名、内容、タグを指定した版の内容に戻す
+// 差し戻しも1件の更新として新しい版が追加される。version は読み込み時の投稿の版数
+func (r *PostRepository) RevertToRevision(ctx context.Context, postID uint32, revision uint32, version uint32, userID uint32) (*model.JoinPost, error) {
+	r.mu.RLock()
+	target, err := r.getRevision(postID, revision)
+	r.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	var postTags []model.PostTag
+	for _, tagID := range target.TagIDs {
+		postTags = append(postTags, model.PostTag{PostID: postID, TagID: tagID})
+	}
+	joinPost := &model.JoinPost{
+		Post: &model.Post{
+			ID:           postID,
+			Title:        target.Revision.Title,
+			Content:      target.Revision.Content,
+			CreateUserID: current.CreateUserID,
+			UpdateUserID: userID,
+			// 読み込み後に他の更新があった場合は差し戻さない
+			Version: version,
+		},
+		PostTags: postTags,
+	}
+	return r.Update(ctx, joinPost)
+}
+
+// DeletePostsByUserID 退会したユーザーIDを元に投稿をゴミ箱に移動する
+func (r *PostRepository) DeletePostsByUserID(ctx context.Context, userID uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	ids := r.trashPosts(func(post model.Post) bool { return post.CreateUserID == userID })
+	r.mu.Unlock()
+
+	r.unindexPosts(ctx, ids...)
+	return nil
+}
+
+// PublishScheduledPosts 公開予定日時を過ぎた下書きを公開し、公開した件数を返す
+func (r *PostRepository) PublishScheduledPosts(ctx context.Context, now time.Time) (int64, error) {
+	var count int64
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, post := range r.posts {
+		if post.DeletedAt != nil || post.Status != interactor.DraftPostStatus || post.PublishAt == nil || post.PublishAt.After(now) {
+			continue
+		}
+		post.Status = interactor.PublishedPostStatus
+		post.UpdatedAt = time.Now()
+		r.posts[id] = post
+		count++
+	}
+	return count, nil
+}
+
+// GetByID IDを元に投稿を1件取得する
+func (r *PostRepository) GetByID(ctx context.Context, ID uint32) (model.Post, error) {
+	if err := ctx.Err(); err != nil {
+		return model.Post{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	post, ok := r.posts[ID]
+	if !ok || post.DeletedAt != nil {
+		return model.Post{}, interactor.NewNotFoundError(interactor.ResourcePost, ID)
+	}
+	return post, nil
+}
+
+// GetJoinPostByID IDを元に投稿、紐付け情報を1件取得する
+// 閲覧ユーザーが参照できない投稿は存在しないものとして扱う
+func (r *PostRepository) GetJoinPostByID(ctx context.Context, ID uint32, viewerID uint32) (model.JoinPost, error) {
+	post, err := r.GetByID(ctx, ID)
+	if err != nil {
+		return model.JoinPost{}, err
+	}
+	if !interactor.IsVisiblePost(&post, viewerID, time.Now()) {
+		return model.JoinPost{}, interactor.NewNotFoundError(interactor.ResourcePost, ID)
+	}
+
+	joinPosts, err := r.createJoinPosts(ctx, []model.Post{post})
+	if err != nil {
+		return model.JoinPost{}, err
+	}
+	return joinPosts[0], nil
+}
+
+// Like 投稿のお気に入り
+// 同じユーザーは1つの投稿に1回のみお気に入りできる
+func (r *PostRepository) Like(ctx context.Context, postData *model.PostLikeUser) (*model.PostLikeUser, error) {
+	if err := ctx.Err(); err != nil {
+		return postData, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.existsPost(postData.PostID) {
+		return postData, interactor.NewNotFoundError(interactor.ResourcePost, postData.PostID)
+	}
+	for _, likeUser := range r.postLikeUsers {
+		if likeUser.PostID == postData.PostID && likeUser.UserID == postData.UserID {
+			return postData, interactor.NewAlreadyLikedError(postData.PostID)
+		}
+	}
+	r.postLikeUsers = append(r.postLikeUsers, *postData)
+	return postData, nil
+}
+
+// NotLike 投稿のお気に入りの取り消し
+func (r *PostRepository) NotLike(ctx context.Context, postData *model.PostLikeUser) (*model.PostLikeUser, error) {
+	if err := ctx.Err(); err != nil {
+		return postData, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var likeUsers []model.PostLikeUser
+	for _, likeUser := range r.postLikeUsers {
+		if likeUser.PostID != postData.PostID || likeUser.UserID != postData.UserID {
+			likeUsers = append(likeUsers, likeUser)
+		}
+	}
+	r.postLikeUsers = likeUsers
+	return postData, nil
+}
+
+// CreateComment コメント作成
+// ゴミ箱に移動した投稿にはコメントできない
+func (r *PostRepository) CreateComment(ctx context.Context, postData *model.Comment) (*model.Comment, error) {
+	if err := model.Validate(postData); err != nil {
+		log.Println("comment validation error", err)
+
+		return postData, err
+	}
+	if err := ctx.Err(); err != nil {
+		return postData, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.existsPost(postData.PostID) {
+		return postData, interactor.NewNotFoundError(interactor.ResourcePost, postData.PostID)
+	}
+	if postData.CommentID == 0 {
+		postData.CommentID = r.lastCommentID + 1
+	}
+	if _, ok := r.comments[postData.CommentID]; ok {
+		return postData, interactor.ErrDuplicateEntry
+	}
+	if postData.CommentID > r.lastCommentID {
+		r.lastCommentID = postData.CommentID
+	}
+	now := time.Now()
+	postData.Version = 1
+	postData.CreatedAt = now
+	postData.UpdatedAt = now
+	r.comments[postData.CommentID] = *postData
+	return postData, nil
+}
+
+// GetCommentByID IDを元にゴミ箱に移動していないコメントを1件取得する
+func (r *PostRepository) GetCommentByID(ctx context.Context, id uint32) (model.Comment, error) {
+	if err := ctx.Err(); err != nil {
+		return model.Comment{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	comment, ok := r.comments[id]
+	if !ok || comment.DeletedAt != nil {
+		return model.Comment{}, interactor.NewNotFoundError(interactor.ResourceComment, id)
+	}
+	return comment, nil
+}
+
+// UpdateComment コメント更新
+func (r *PostRepository) UpdateComment(ctx context.Context, postData *model.Comment) (*model.Comment, error) {
+	if err := model.Validate(postData); err != nil {
+		return postData, err
+	}
+	if err := ctx.Err(); err != nil {
+		return postData, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	comment, ok := r.comments[postData.CommentID]
+	if !ok || comment.DeletedAt != nil {
+		return postData, interactor.NewNotFoundError(interactor.ResourceComment, postData.CommentID)
+	}
+	// 更新前の版数が一致する場合のみ更新する
+	if postData.Version == 0 {
+		return postData, interactor.ErrVersionRequired
+	}
+	if comment.Version != postData.Version {
+		return postData, interactor.NewVersionConflictError(interactor.ResourceComment, postData.CommentID)
+	}
+	comment.Version++
+	comment.CommentContent = postData.CommentContent
+	comment.UpdatedAt = time.Now()
+	r.comments[comment.CommentID] = comment
+	// 更新後のコメントを返す
+	*postData = comment
+	return postData, nil
+}
+
+// DeleteComment 指定されたIDに対するコメント1件をゴミ箱に移動する
+func (r *PostRepository) DeleteComment(ctx context.Context, id uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trashComments(func(comment model.Comment) bool { return comment.CommentID == id })
+	return nil
+}
+
+// DeleteCommentsByUserID 退会したユーザーIDを元にコメントをゴミ箱に移動する
+func (r *PostRepository) DeleteCommentsByUserID(ctx context.Context, userID uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trashComments(func(comment model.Comment) bool { return comment.CreateUserID == userID })
+	return nil
+}
+
+// createJoinPosts 投稿に紐付け情報、ユーザー情報を付与する
+func (r *PostRepository) createJoinPosts(ctx context.Context, posts []model.Post) ([]model.JoinPost, error) {
+	if len(posts) == 0 {
+		return []model.JoinPost{}, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return []model.JoinPost{}, err
+	}
+
+	ids := map[uint32]bool{}
+	for _, post := range posts {
+		ids[post.ID] = true
+	}
+	relations := interactor.PostRelations{
+		PostTags:      map[uint32][]model.PostTag{},
+		PostLikeUsers: map[uint32][]model.PostLikeUser{},
+		Comments:      map[uint32][]model.Comment{},
+	}
+
+	r.mu.RLock()
+	// 有効なタグのみ紐付ける
+	for _, postTag := range r.postTags {
+		if ids[postTag.PostID] && r.tags != nil && r.tags.isValidTag(postTag.TagID) {
+			relations.PostTags[postTag.PostID] = append(relations.PostTags[postTag.PostID], postTag)
+		}
+	}
+	for _, likeUser := range r.postLikeUsers {
+		if ids[likeUser.PostID] {
+			relations.PostLikeUsers[likeUser.PostID] = append(relations.PostLikeUsers[likeUser.PostID], likeUser)
+		}
+	}
+	var comments []model.Comment
+	for _, comment := range r.comments {
+		if ids[comment.PostID] && comment.DeletedAt == nil {
+			comments = append(comments, comment)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(comments, func(a, b int) bool {
+		if !comments[a].CreatedAt.Equal(comments[b].CreatedAt) {
+			return comments[a].CreatedAt.Before(comments[b].CreatedAt)
+		}
+		return comments[a].CommentID < comments[b].CommentID
+	})
+	for _, comment := range comments {
+		relations.Comments[comment.PostID] = append(relations.Comments[comment.PostID], comment)
+	}
+
+	users := interactor.LookupUsers(ctx, r.Users, posts, relations)
+	return interactor.MakeJoinPosts(posts, relations, users), nil
+}
+
+// matchFilter 投稿が絞り込み条件を満たすか判定する
+// filterPosts と同じ条件を表す
+func (r *PostRepository) matchFilter(post model.Post, filter model.PostFilter) bool {
+	if len(filter.CreateUserIDs) > 0 && !containsID(filter.CreateUserIDs, post.CreateUserID) {
+		return false
+	}
+	if tagIDs := interactor.UniqueIDs(filter.TagIDs); len(tagIDs) > 0 {
+		matched := map[uint32]bool{}
+		for _, postTag := range r.postTags {
+			if postTag.PostID == post.ID && containsID(tagIDs, postTag.TagID) {
+				matched[postTag.TagID] = true
+			}
+		}
+		if filter.TagMatch == model.TagMatchAll && len(matched) != len(tagIDs) {
+			return false
+		}
+		if len(matched) == 0 {
+			return false
+		}
+	}
+	if filter.LikedByUserID != 0 {
+		liked := false
+		for _, likeUser := range r.postLikeUsers {
+			if likeUser.PostID == post.ID && likeUser.UserID == filter.LikedByUserID {
+				liked = true
+				break
+			}
+		}
+		if !liked {
+			return false
+		}
+	}
+	if filter.CreatedAfter != nil && post.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && !post.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+	switch filter.Image {
+	case model.ImageWith:
+		if post.Image == "" {
+			return false
+		}
+	case model.ImageWithout:
+		if post.Image != "" {
+			return false
+		}
+	}
+	commentCount := uint32(r.countComments(post.ID))
+	if commentCount < filter.MinCommentCount {
+		return false
+	}
+	if filter.MaxCommentCount != nil && commentCount > *filter.MaxCommentCount {
+		return false
+	}
+	return true
+}
+
+// sortKey 並び順ごとの並び替えの値を求める
+// sortKeyQuery と同じ値を表し、作成日時順の場合は0を返す
+func (r *PostRepository) sortKey(post model.Post, sort model.PostSort, now time.Time) int64 {
+	switch sort {
+	case model.PostSortMostLikes:
+		return r.countLikes(post.ID)
+	case model.PostSortMostComments:
+		return r.countComments(post.ID)
+	case model.PostSortTrending:
+		return interactor.TrendingScore(r.countLikes(post.ID)+r.countComments(post.ID), post.CreatedAt, now)
+	}
+	return 0
+}
+
+// countLikes 投稿のいいね数を返す
+func (r *PostRepository) countLikes(postID uint32) int64 {
+	var count int64
+	for _, likeUser := range r.postLikeUsers {
+		if likeUser.PostID == postID {
+			count++
+		}
+	}
+	return count
+}
+
+// countComments 投稿のコメント数(削除済みを除く)を返す
+func (r *PostRepository) countComments(postID uint32) int64 {
+	var count int64
+	for _, comment := range r.comments {
+		if comment.PostID == postID && comment.DeletedAt == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// trashPosts 条件に一致する投稿をゴミ箱に移動し、移動した投稿のIDを返す
+func (r *PostRepository) trashPosts(match func(post model.Post) bool) []uint32 {
+	var ids []uint32
+	now := time.Now()
+	for id, post := range r.posts {
+		if post.DeletedAt != nil || !match(post) {
+			continue
+		}
+		post.DeletedAt = &now
+		r.posts[id] = post
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// trashComments 条件に一致するコメントをゴミ箱に移動する
+func (r *PostRepository) trashComments(match func(comment model.Comment) bool) {
+	now := time.Now()
+	for id, comment := range r.comments {
+		if comment.DeletedAt != nil || !match(comment) {
+			continue
+		}
+		comment.DeletedAt = &now
+		r.comments[id] = comment
+	}
+}
+
+// purgePosts 投稿と、紐付けられたタグ、お気に入り、コメント、更新履歴を削除する
+func (r *PostRepository) purgePosts(ids []uint32) {
+	if len(ids) == 0 {
+		return
+	}
+	purged := map[uint32]bool{}
+	for _, id := range ids {
+		purged[id] = true
+	}
+	r.postTags = filterPostTags(r.postTags, func(postTag model.PostTag) bool { return !purged[postTag.PostID] })
+
+	var likeUsers []model.PostLikeUser
+	for _, likeUser := range r.postLikeUsers {
+		if !purged[likeUser.PostID] {
+			likeUsers = append(likeUsers, likeUser)
+		}
+	}
+	r.postLikeUsers = likeUsers
+
+	for id, comment := range r.comments {
+		if purged[comment.PostID] {
+			delete(r.comments, id)
+		}
+	}
+
+	purgedRevisions := map[uint32]bool{}
+	var revisions []model.PostRevision
+	for _, revision := range r.revisions {
+		if purged[revision.PostID] {
+			purgedRevisions[revision.ID] = true
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+	r.revisions = revisions
+
+	var revisionTags []model.PostRevisionTag
+	for _, revisionTag := range r.revisionTags {
+		if !purgedRevisions[revisionTag.RevisionID] {
+			revisionTags = append(revisionTags, revisionTag)
+		}
+	}
+	r.revisionTags = revisionTags
+
+	for _, id := range ids {
+		delete(r.posts, id)
+	}
+}
+
+// recordRevision 投稿の現在の内容を新しい版として登録する
+func (r *PostRepository) recordRevision(postID uint32, updateUserID uint32, now time.Time) {
+	var latest uint32
+	post := r.posts[postID]
+	for _, revision := range r.revisions {
+		if revision.PostID == postID && revision.Revision > latest {
+			latest = revision.Revision
+		}
+	}
+
+	r.lastRevisionID++
+	r.revisions = append(r.revisions, model.PostRevision{
+		ID:           r.lastRevisionID,
+		PostID:       postID,
+		Revision:     latest + 1,
+		Title:        post.Title,
+		Content:      post.Content,
+		UpdateUserID: updateUserID,
+		CreatedAt:    now,
+	})
+	for _, postTag := range r.postTags {
+		if postTag.PostID == postID {
+			r.revisionTags = append(r.revisionTags, model.PostRevisionTag{RevisionID: r.lastRevisionID, TagID: postTag.TagID})
+		}
+	}
+}
+
+// hasRevision 投稿に更新履歴があるか判定する
+func (r *PostRepository) hasRevision(postID uint32) bool {
+	for _, revision := range r.revisions {
+		if revision.PostID == postID {
+			return true
+		}
+	}
+	return false
+}
+
+// getRevision 投稿IDと版数を元に更新履歴を1件取得する
+func (r *PostRepository) getRevision(postID uint32, revision uint32) (model.JoinPostRevision, error) {
+	for _, row := range r.revisions {
+		if row.PostID == postID && row.Revision == revision {
+			return r.joinRevisionTags(row), nil
+		}
+	}
+	return model.JoinPostRevision{}, interactor.NewRevisionNotFoundError(postID)
+}
+
+// joinRevisionTags 更新履歴に版ごとのタグIDを紐付けて返す
+func (r *PostRepository) joinRevisionTags(revision model.PostRevision) model.JoinPostRevision {
+	var tagIDs []uint32
+	for _, revisionTag := range r.revisionTags {
+		if revisionTag.RevisionID == revision.ID {
+			tagIDs = append(tagIDs, revisionTag.TagID)
+		}
+	}
+	sort.Slice(tagIDs, func(a, b int) bool { return tagIDs[a] < tagIDs[b] })
+	return model.JoinPostRevision{Revision: revision, TagIDs: tagIDs}
+}
+
+// indexPost 投稿を全文検索インデックスに登録する
+// インデックスの更新に失敗しても投稿の更新は取り消さない
+func (r *PostRepository) indexPost(ctx context.Context, postID uint32) {
+	var tagIDs []uint32
+	if r.SearchIndex == nil {
+		return
+	}
+
+	r.mu.RLock()
+	post, ok := r.posts[postID]
+	for _, postTag := range r.postTags {
+		if postTag.PostID == postID {
+			tagIDs = append(tagIDs, postTag.TagID)
+		}
+	}
+	r.mu.RUnlock()
+	if !ok || post.DeletedAt != nil {
+		log.Printf("Error happend while indexing post ID: %v\n", postID)
+		return
+	}
+	if err := r.SearchIndex.Index(ctx, post, tagIDs); err != nil {
+		log.Printf("Error happend while indexing post ID: %v, %v\n", postID, err)
+	}
+}
+
+// unindexPosts 投稿を全文検索インデックスから削除する
+func (r *PostRepository) unindexPosts(ctx context.Context, ids ...uint32) {
+	if r.SearchIndex == nil {
+		return
+	}
+	for _, id := range ids {
+		if err := r.SearchIndex.Remove(ctx, id); err != nil {
+			log.Printf("Error happend while removing post ID: %v from index, %v\n", id, err)
+		}
+	}
+}
+
+// pageSortedPosts 並び順に並べ、カーソル位置より後ろの投稿を最大limit件返す
+// 並び替えの値が大きい順、同じ場合は paginatePostsBy と同じ作成日時順とする
+func pageSortedPosts(rows []interactor.SortedPost, sortBy model.PostSort, cursor *interactor.PostCursor, limit uint32) []interactor.SortedPost {
+	before := func(a interactor.SortedPost, b interactor.SortedPost) bool {
+		if a.SortKey != b.SortKey {
+			return a.SortKey > b.SortKey
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			if sortBy == model.PostSortOldest {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		if sortBy == model.PostSortOldest {
+			return a.ID < b.ID
+		}
+		return a.ID > b.ID
+	}
+	sort.Slice(rows, func(a, b int) bool { return before(rows[a], rows[b]) })
+
+	if cursor != nil {
+		position := interactor.SortedPost{
+			Post:    model.Post{ID: cursor.ID, CreatedAt: time.Unix(0, cursor.CreatedAt)},
+			SortKey: cursor.Key,
+		}
+		start := sort.Search(len(rows), func(i int) bool { return before(position, rows[i]) })
+		rows = rows[start:]
+	}
+	if uint32(len(rows)) > limit {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// unwrapSortedPosts 並び替えの値を除いた投稿を返す
+func unwrapSortedPosts(rows []interactor.SortedPost) []model.Post {
+	posts := make([]model.Post, 0, len(rows))
+	for _, row := range rows {
+		posts = append(posts, row.Post)
+	}
+	return posts
+}
+
+// filterPostTags 条件を満たすタグ紐付け情報のみを返す
+func filterPostTags(postTags []model.PostTag, keep func(postTag model.PostTag) bool) []model.PostTag {
+	var kept []model.PostTag
+	for _, postTag := range postTags {
+		if keep(postTag) {
+			kept = append(kept, postTag)
+		}
+	}
+	return kept
+}
+
+// containsID idsにidが含まれるか判定する
+func containsID(ids []uint32, id uint32) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// CheckIntegrity 参照先が存在しない紐付け情報を検査し、見つかった件数を返す
+// repairがtrueの場合は、見つかった紐付け情報を削除する
+func (r *PostRepository) CheckIntegrity(ctx context.Context, repair bool) ([]model.IntegrityIssue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var issues []model.IntegrityIssue
+	report := func(table string, reference string, count int) {
+		if count == 0 {
+			return
+		}
+		issues = append(issues, model.IntegrityIssue{Table: table, Reference: reference, Count: int64(count), Repaired: repair})
+	}
+
+	postTags, orphans := filterRelations(len(r.postTags), func(n int) bool {
+		_, ok := r.posts[r.postTags[n].PostID]
+		return ok
+	})
+	report("post_tags", "posts", orphans)
+	var validPostTags []model.PostTag
+	for _, n := range postTags {
+		validPostTags = append(validPostTags, r.postTags[n])
+	}
+	tagged, orphans := filterRelations(len(validPostTags), func(n int) bool {
+		return r.tags == nil || r.tags.hasTag(validPostTags[n].TagID)
+	})
+	report("post_tags", "tags", orphans)
+
+	likes, orphans := filterRelations(len(r.postLikeUsers), func(n int) bool {
+		_, ok := r.posts[r.postLikeUsers[n].PostID]
+		return ok
+	})
+	report("post_like_users", "posts", orphans)
+
+	var orphanComments []uint32
+	for id, comment := range r.comments {
+		if _, ok := r.posts[comment.PostID]; !ok {
+			orphanComments = append(orphanComments, id)
+		}
+	}
+	report("comments", "posts", len(orphanComments))
+
+	revisions, orphans := filterRelations(len(r.revisions), func(n int) bool {
+		_, ok := r.posts[r.revisions[n].PostID]
+		return ok
+	})
+	report("post_revisions", "posts", orphans)
+	revisionIDs := map[uint32]bool{}
+	for _, n := range revisions {
+		revisionIDs[r.revisions[n].ID] = true
+	}
+	revisionTags, orphans := filterRelations(len(r.revisionTags), func(n int) bool {
+		return revisionIDs[r.revisionTags[n].RevisionID]
+	})
+	report("post_revision_tags", "post_revisions", orphans)
+
+	if !repair {
+		return issues, nil
+	}
+	var keptPostTags []model.PostTag
+	for _, n := range tagged {
+		keptPostTags = append(keptPostTags, validPostTags[n])
+	}
+	r.postTags = keptPostTags
+	var keptLikes []model.PostLikeUser
+	for _, n := range likes {
+		keptLikes = append(keptLikes, r.postLikeUsers[n])
+	}
+	r.postLikeUsers = keptLikes
+	for _, id := range orphanComments {
+		delete(r.comments, id)
+	}
+	var keptRevisions []model.PostRevision
+	for _, n := range revisions {
+		keptRevisions = append(keptRevisions, r.revisions[n])
+	}
+	r.revisions = keptRevisions
+	var keptRevisionTags []model.PostRevisionTag
+	for _, n := range revisionTags {
+		keptRevisionTags = append(keptRevisionTags, r.revisionTags[n])
+	}
+	r.revisionTags = keptRevisionTags
+	return issues, nil
+}
+
+// filterRelations 参照先が存在する紐付けの添字と、存在しない紐付けの件数を返す
+func filterRelations(length int, exists func(n int) bool) ([]int, int) {
+	var kept []int
+	for n := 0; n < length; n++ {
+		if exists(n) {
+			kept = append(kept, n)
+		}
+	}
+	return kept, length - len(kept)
+}
+
+// existsPost ゴミ箱に移動していない投稿が存在するか判定する
+// 呼び出し元でロックを取得しておく
+func (r *PostRepository) existsPost(id uint32) bool {
+	post, ok := r.posts[id]
+	return ok && post.DeletedAt == nil
+}
+
+// removePostTagsByTagID 削除したタグの投稿への紐付けを削除する
+func (r *PostRepository) removePostTagsByTagID(tagID uint32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var postTags []model.PostTag
+	for _, postTag := range r.postTags {
+		if postTag.TagID != tagID {
+			postTags = append(postTags, postTag)
+		}
+	}
+	r.postTags = postTags
+}
+
+// InsertUnchecked 参照先を検査せずに紐付け情報を登録する
+// DBの外部キー制約を無効にして登録した場合と同じく、参照先のない紐付け情報を検査するテストで用いる
+// IDが0のコメント、更新履歴は採番する
+func (r *PostRepository) InsertUnchecked(values ...interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, value := range values {
+		switch v := value.(type) {
+		case *model.PostTag:
+			r.postTags = append(r.postTags, *v)
+		case *model.PostLikeUser:
+			r.postLikeUsers = append(r.postLikeUsers, *v)
+		case *model.Comment:
+			if v.CommentID == 0 {
+				v.CommentID = r.lastCommentID + 1
+			}
+			if v.CommentID > r.lastCommentID {
+				r.lastCommentID = v.CommentID
+			}
+			r.comments[v.CommentID] = *v
+		case *model.PostRevision:
+			if v.ID == 0 {
+				v.ID = r.lastRevisionID + 1
+			}
+			if v.ID > r.lastRevisionID {
+				r.lastRevisionID = v.ID
+			}
+			r.revisions = append(r.revisions, *v)
+		case *model.PostRevisionTag:
+			r.revisionTags = append(r.revisionTags, *v)
+		default:
+			return fmt.Errorf("unsupported value %T", value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// TagRepository プロセス内にタグを保持するTagRepositoryの実装
+// 永続化しないため、テストでの利用を想定する
+type TagRepository struct {
+	mu     sync.RWMutex
+	tags   map[uint32]model.Tag
+	lastID uint32
+
+	// タグを紐付ける投稿の参照先(タグ削除時に紐付けを削除する)
+	posts *PostRepository
+}
+
+var _ repository.TagRepository = (*TagRepository)(nil)
+
+// NewTagRepository 空のTagRepositoryを生成する
+func NewTagRepository() *TagRepository {
+	return &TagRepository{
+		tags: map[uint32]model.Tag{},
+	}
+}
+
+// Create タグ1件を作成
+func (r *TagRepository) Create(ctx context.Context, postData *model.Tag) (*model.Tag, error) {
+	// Tag構造体のバリデーション
+	if err := model.Validate(postData); err != nil {
+		return postData, err
+	}
+	if err := ctx.Err(); err != nil {
+		return postData, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if postData.ID == 0 {
+		postData.ID = r.lastID + 1
+	}
+	if _, ok := r.tags[postData.ID]; ok {
+		return postData, interactor.ErrDuplicateEntry
+	}
+	if postData.ID > r.lastID {
+		r.lastID = postData.ID
+	}
+	now := time.Now()
+	if postData.CreatedAt.IsZero() {
+		postData.CreatedAt = now
+	}
+	if postData.UpdatedAt.IsZero() {
+		postData.UpdatedAt = now
+	}
+	r.tags[postData.ID] = *postData
+	return postData, nil
+}
+
+// DeleteByID 指定したIDのタグ1件を、投稿への紐付けと共に削除
+func (r *TagRepository) DeleteByID(ctx context.Context, id uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	delete(r.tags, id)
+	posts := r.posts
+	r.mu.Unlock()
+
+	// 投稿側はタグのロックを取得するため、タグのロックを解除してから削除する
+	if posts != nil {
+		posts.removePostTagsByTagID(id)
+	}
+	return nil
+}
+
+// GetTagByTagName TagNameを元にタグを1件取得する
+func (r *TagRepository) GetTagByTagName(ctx context.Context, tagName string) (model.Tag, error) {
+	tags, err := r.find(ctx, func(tag model.Tag) bool { return tag.TagName == tagName })
+	if err != nil {
+		return model.Tag{}, err
+	}
+	if len(tags) == 0 {
+		return model.Tag{}, interactor.NewNotFoundError(interactor.ResourceTag, 0)
+	}
+	// 同名のタグが複数ある場合はIDの小さいものを返す
+	sort.Slice(tags, func(a, b int) bool { return tags[a].ID < tags[b].ID })
+	return tags[0], nil
+}
+
+// GetTagByTagID TagIDを元にタグを1件取得する
+func (r *TagRepository) GetTagByTagID(ctx context.Context, tagID uint32) (model.Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return model.Tag{}, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tag, ok := r.tags[tagID]
+	if !ok {
+		return model.Tag{}, interactor.NewNotFoundError(interactor.ResourceTag, tagID)
+	}
+	return tag, nil
+}
+
+// ListValidTag 有効タグを全件取得する
+// TagInteractorと同じく、ID、タグ名、ステータスのみを返す
+func (r *TagRepository) ListValidTag(ctx context.Context) ([]model.Tag, error) {
+	rows, err := r.find(ctx, func(tag model.Tag) bool { return tag.Status == interactor.ValidTagStatus })
+	if err != nil {
+		return []model.Tag{}, err
+	}
+	var tags []model.Tag
+	for _, row := range sortTagsByCreatedAt(rows) {
+		tags = append(tags, model.Tag{ID: row.ID, TagName: row.TagName, Status: row.Status})
+	}
+	return tags, nil
+}
+
+// List タグを全件取得
+func (r *TagRepository) List(ctx context.Context) ([]model.Tag, error) {
+	rows, err := r.find(ctx, func(tag model.Tag) bool { return true })
+	if err != nil {
+		return []model.Tag{}, err
+	}
+	return sortTagsByCreatedAt(rows), nil
+}
+
+// Update タグを更新する
+// 指定したIDのタグのみ、値が設定された項目を更新する
+func (r *TagRepository) Update(ctx context.Context, postData *model.Tag) (*model.Tag, error) {
+	// Tag構造体のバリデーション
+	if err := model.Validate(postData); err != nil {
+		return postData, err
+	}
+	if err := ctx.Err(); err != nil {
+		return postData, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tag, ok := r.tags[postData.ID]
+	if !ok {
+		return postData, nil
+	}
+	if postData.TagName != "" {
+		tag.TagName = postData.TagName
+	}
+	// 作成ユーザーは変更しない
+	if postData.UpdateUserID != 0 {
+		tag.UpdateUserID = postData.UpdateUserID
+	}
+	if postData.Status != 0 {
+		tag.Status = postData.Status
+	}
+	tag.UpdatedAt = time.Now()
+	postData.UpdatedAt = tag.UpdatedAt
+	r.tags[tag.ID] = tag
+	return postData, nil
+}
+
+// setPosts タグを紐付ける投稿の参照先を設定する
+func (r *TagRepository) setPosts(posts *PostRepository) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.posts = posts
+}
+
+// hasTag タグが登録されているか判定する
+func (r *TagRepository) hasTag(id uint32) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.tags[id]
+	return ok
+}
+
+// isValidTag 有効なタグとして登録されているか判定する
+func (r *TagRepository) isValidTag(id uint32) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tag, ok := r.tags[id]
+	return ok && tag.Status == interactor.ValidTagStatus
+}
+
+// find 条件に一致するタグを返す
+func (r *TagRepository) find(ctx context.Context, match func(tag model.Tag) bool) ([]model.Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var tags []model.Tag
+	for _, tag := range r.tags {
+		if match(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// sortTagsByCreatedAt タグを作成日時の新しい順に並べる
+func sortTagsByCreatedAt(tags []model.Tag) []model.Tag {
+	sort.Slice(tags, func(a, b int) bool {
+		if !tags[a].CreatedAt.Equal(tags[b].CreatedAt) {
+			return tags[a].CreatedAt.After(tags[b].CreatedAt)
+		}
+		return tags[a].ID > tags[b].ID
+	})
+	return tags
+}
@@ -0,0 +1,21 @@
+package interactor_test
+
+import (
+	"testing"
+
+	"github.com/yzmw1213/PostService/search"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+	"github.com/yzmw1213/PostService/usecase/interactor/memory"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// TestMemoryRepositoryConformance memory.PostRepository, memory.TagRepositoryの共通仕様をテスト
+// memoryパッケージはinteractorに依存するため、外部パッケージのテストとして実行する
+func TestMemoryRepositoryConformance(t *testing.T) {
+	interactor.RepositoryConformance(t, func(t *testing.T) (repository.PostRepository, repository.TagRepository) {
+		tags := memory.NewTagRepository()
+		posts := memory.NewPostRepository(tags)
+		posts.SearchIndex = search.NewMemoryIndex()
+		return posts, tags
+	})
+}
@@ -5,14 +5,12 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"os"
+	"time"
 
-	"github.com/go-playground/validator/v10"
-	"google.golang.org/grpc"
+	"github.com/jinzhu/gorm"
 
 	"github.com/yzmw1213/PostService/db"
 	"github.com/yzmw1213/PostService/domain/model"
-	"github.com/yzmw1213/PostService/grpc/userservice"
 	"github.com/yzmw1213/PostService/usecase/repository"
 )
 
@@ -27,240 +25,259 @@ var (
 	likeUser  model.PostLikeUser
 	likeUsers []model.PostLikeUser
 	rows      *sql.Rows
-	validate  *validator.Validate
 )
 
 // PostInteractor 投稿サービスを提供するメソッド群
-type PostInteractor struct{}
+type PostInteractor struct {
+	// 全文検索インデックス
+	SearchIndex repository.SearchIndex
+	// ユーザー情報の参照先(未設定の場合は全てのユーザーをUnknownUserNameとして扱う)
+	Users repository.UserDirectory
+}
 
 var _ repository.PostRepository = (*PostInteractor)(nil)
 
 // Create 投稿1件を作成
-func (p *PostInteractor) Create(postData *model.JoinPost) (*model.JoinPost, error) {
-	validate = validator.New()
+func (p *PostInteractor) Create(ctx context.Context, postData *model.JoinPost) (*model.JoinPost, error) {
 	post := postData.Post
 	tags := postData.PostTags
 
 	// Post構造体のバリデーション
-	if err := validate.Struct(post); err != nil {
+	if err := model.Validate(post); err != nil {
 		return postData, err
 	}
 
-	// トランザクション開始
-	tx := db.StartBegin()
-
-	// 投稿登録
-	if err := tx.Create(post).Error; err != nil {
-		db.EndRollback()
+	// 作成時のステータスを決定
+	status, err := InitialPostStatus(post.Status)
+	if err != nil {
 		return postData, err
 	}
+	post.Status = status
+	post.Version = 1
+	// 公開予定日時が未来の場合は予約投稿として下書きで登録する
+	if IsScheduled(post, time.Now()) {
+		post.Status = DraftPostStatus
+	}
 
-	postID := post.ID
-	// 投稿とタグ紐付け情報登録
-	for _, tag := range tags {
-		tag.PostID = postID
-		if err := tx.Create(tag).Error; err != nil {
-			db.EndRollback()
-			return postData, err
+	// 投稿、タグ紐付け情報、最初の版を1つのトランザクションで登録
+	err = db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+
+		// 投稿登録
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+
+		// 投稿とタグ紐付け情報登録
+		for _, tag := range tags {
+			tag.PostID = post.ID
+			if err := tx.Create(tag).Error; err != nil {
+				return err
+			}
 		}
+		// 作成時の内容を最初の版として登録
+		return recordRevision(tx, post.ID, post.CreateUserID)
+	})
+	if err != nil {
+		return postData, err
 	}
-	// トランザクションを終了しコミット
-	db.EndCommit()
-	return postData, err
+	p.indexPost(ctx, post.ID)
+	return postData, nil
 }
 
-// DeleteByID 指定されたIDに対する投稿1件を削除
-func (p *PostInteractor) DeleteByID(id uint32) error {
+// DeleteByID 指定されたIDに対する投稿1件をゴミ箱に移動する
+// タグ、お気に入り、コメントの紐付けは復元できるよう完全削除まで残す
+func (p *PostInteractor) DeleteByID(ctx context.Context, id uint32) error {
 	var post model.Post
-	var postTag model.PostTag
+	DB := db.Conn(ctx)
 
-	// トランザクション開始
-	tx := db.StartBegin()
-	// 指定されたPostIDのPostを削除
-	if err := tx.Where("id = ?", id).Delete(&post).Error; err != nil {
-		db.EndRollback()
+	// 指定されたPostIDのPostを論理削除
+	if err := DB.Where("id = ?", id).Delete(&post).Error; err != nil {
 		return err
 	}
-	// 指定されたPostIDのPostTagを削除
-	if err := tx.Where("post_id = ?", id).Delete(&postTag).Error; err != nil {
-		db.EndRollback()
-		return err
-	}
-	// トランザクションを終了しコミット
-	db.EndCommit()
+	p.unindexPosts(ctx, id)
 	return nil
 }
 
-// List 条件に応じて投稿を取得
-func (p *PostInteractor) List(condition string, id uint32) ([]model.JoinPost, error) {
-	var rows []model.Post
-	switch condition {
-	case "create":
-		rows, err = getPostsByCreateUserID(context.Background(), id)
-		break
-	case "like":
-		rows, err = getPostsByLikeUserID(context.Background(), id)
-		break
-	case "tag":
-		rows, err = getPostsByTagID(context.Background(), id)
-		break
-	default:
-		rows, err = getAllPosts(context.Background())
-	}
+// List 条件に応じて投稿を1ページ分取得し、次ページのトークンと共に返す
+// 公開中以外の投稿は閲覧ユーザーが投稿者本人の場合のみ返す
+func (p *PostInteractor) List(ctx context.Context, condition model.PostListCondition, page model.Pagination) ([]model.JoinPost, string, error) {
+	cursor, err := DecodePostCursor(page.PageToken)
 	if err != nil {
-		fmt.Println("Error happened")
-		return []model.JoinPost{}, err
+		return []model.JoinPost{}, "", err
 	}
-	// 取得したpostsに紐付け情報を付与して返す
-	return createJoinPosts(rows)
-}
-
-// getAllPosts 全件取得
-func getAllPosts(ctx context.Context) ([]model.Post, error) {
-	var posts []model.Post
-	DB := db.GetDB()
-
-	_, err := DB.Order("created_at desc").Find(&posts).Rows()
-
-	return posts, err
-}
-
-// getPostsByCreateUserID 作成ユーザーIDで検索
-func getPostsByCreateUserID(ctx context.Context, id uint32) ([]model.Post, error) {
-	var posts []model.Post
-	DB := db.GetDB()
-
-	rows, err := DB.Order("created_at desc").Where("create_user_id = ?", id).Find(&posts).Rows()
-	defer rows.Close()
-
+	size := NormalizePageSize(page.PageSize)
+	filter, err := ListConditionFilter(condition)
 	if err != nil {
-		log.Println("Error occured")
-		return nil, err
+		return []model.JoinPost{}, "", err
 	}
-	return posts, nil
-}
-
-// getPostsByLikeUserID いいねしたユーザーIDで検索
-func getPostsByLikeUserID(ctx context.Context, id uint32) ([]model.Post, error) {
-	var posts []model.Post
-	DB := db.GetDB()
-
-	rows, err := DB.Order("created_at desc").Table("posts").Where("post_like_users.user_id = ?", id).Select("posts.id, posts.title, posts.content, posts.create_user_id, posts.image").Joins("inner join post_like_users on post_like_users.post_id = posts.id").Rows()
-	defer rows.Close()
-
-	for rows.Next() {
-		DB.ScanRows(rows, &post)
-		posts = append(posts, post)
+	if err := ValidatePostFilter(filter); err != nil {
+		return []model.JoinPost{}, "", err
 	}
-	if err != nil {
-		log.Println("Error occured")
-		return nil, err
+	if err := ValidatePostSort(condition.Sort, cursor); err != nil {
+		return []model.JoinPost{}, "", err
 	}
-	return posts, nil
-}
+	query := filterPosts(visiblePosts(db.Conn(ctx), condition.ViewerID, condition.Status), filter)
 
-// getPostsByTagID タグIDで検索
-func getPostsByTagID(ctx context.Context, id uint32) ([]model.Post, error) {
-	var posts []model.Post
-	DB := db.GetDB()
-
-	rows, err := DB.Order("created_at desc").Table("posts").Where("post_tags.tag_id = ?", id).Select("posts.id, posts.title, posts.content, posts.create_user_id, posts.image").Joins("inner join post_tags on post_tags.post_id = posts.id").Rows()
-	defer rows.Close()
-
-	for rows.Next() {
-		DB.ScanRows(rows, &post)
-		posts = append(posts, post)
+	rows, nextPageToken, err := getSortedPosts(query, condition.Sort, cursor, size)
+	if err != nil {
+		fmt.Println("Error happened")
+		return []model.JoinPost{}, "", err
 	}
+
+	// 取得したpostsに紐付け情報を付与して返す
+	joinPosts, err := p.createJoinPosts(ctx, rows)
 	if err != nil {
-		log.Println("Error occured")
-		return nil, err
+		return []model.JoinPost{}, "", err
 	}
-	return posts, nil
+	return joinPosts, nextPageToken, nil
 }
 
 // Update 投稿を更新する
-func (p *PostInteractor) Update(postData *model.JoinPost) (*model.JoinPost, error) {
-	validate = validator.New()
+func (p *PostInteractor) Update(ctx context.Context, postData *model.JoinPost) (*model.JoinPost, error) {
 	post := postData.Post
 	tags := postData.PostTags
 
 	// Post構造体のバリデーション
-	if err := validate.Struct(post); err != nil {
+	if err := model.Validate(post); err != nil {
 		return postData, err
 	}
 
-	// トランザクション開始
-	tx := db.StartBegin()
-
-	if err := tx.Model(&post).Update(&postData.Post).Error; err != nil {
-		db.EndRollback()
-		return postData, err
+	// ステータスが指定された場合は遷移可能か判定
+	var clearPublishAt bool
+	if post.Status != 0 {
+		current, err := p.GetByID(ctx, post.ID)
+		if err != nil {
+			return postData, err
+		}
+		if err := CheckPostStatusChange(ctx, current, post.Status); err != nil {
+			return postData, err
+		}
+		clearPublishAt = ClearsPublishAt(current.Status, post)
 	}
 
-	// 投稿とタグ紐付け情報を全て削除
-	deletePostTagByPostID(post.ID)
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
 
-	postID := post.ID
-	// 投稿とタグ紐付け情報登録
-	for _, tag := range tags {
-		tag.PostID = postID
-		if err := tx.Create(tag).Error; err != nil {
-			db.EndRollback()
-			return postData, err
+		// 更新前の版数が一致する場合のみ更新する
+		version, err := incrementVersion(tx, &model.Post{}, ResourcePost, "id", post.ID, post.Version)
+		if err != nil {
+			return err
 		}
+
+		// 更新履歴がない場合は更新前の内容を残す
+		if err := recordInitialRevision(tx, post.ID); err != nil {
+			return err
+		}
+
+		// 作成ユーザーは変更しない
+		if err := tx.Model(&post).Omit("version", "create_user_id").Update(&postData.Post).Error; err != nil {
+			return err
+		}
+		post.Version = version
+		if clearPublishAt {
+			if err := tx.Model(&model.Post{}).Where("id = ?", post.ID).Update("publish_at", gorm.Expr("NULL")).Error; err != nil {
+				return err
+			}
+		}
+
+		// 投稿とタグ紐付け情報を全て削除
+		if err := deletePostTagByPostID(ctx, post.ID); err != nil {
+			return err
+		}
+
+		// 投稿とタグ紐付け情報登録
+		for _, tag := range tags {
+			tag.PostID = post.ID
+			if err := tx.Create(tag).Error; err != nil {
+				return err
+			}
+		}
+		// 更新後の内容を新しい版として登録
+		return recordRevision(tx, post.ID, post.UpdateUserID)
+	})
+	if err != nil {
+		return postData, err
 	}
-	// トランザクションを終了しコミット
-	db.EndCommit()
+	p.indexPost(ctx, post.ID)
 	return postData, nil
 }
 
-// DeletePostsByUserID 退会したユーザーIDを元に投稿を削除する
-func (p *PostInteractor) DeletePostsByUserID(userID uint32) error {
+// PublishScheduledPosts 公開予定日時を過ぎた下書きを公開し、公開した件数を返す
+func (p *PostInteractor) PublishScheduledPosts(ctx context.Context, now time.Time) (int64, error) {
+	DB := db.Conn(ctx)
+	result := DB.Model(&model.Post{}).
+		Where("status = ? AND publish_at <= ?", DraftPostStatus, now).
+		Update("status", PublishedPostStatus)
+	if err := result.Error; err != nil {
+		log.Println("Error occured while publishing scheduled posts")
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// DeletePostsByUserID 退会したユーザーIDを元に投稿をゴミ箱に移動する
+func (p *PostInteractor) DeletePostsByUserID(ctx context.Context, userID uint32) error {
 	var post model.Post
-	// トランザクション開始
-	tx := db.StartBegin()
-	err := tx.Where("create_user_id = ?", userID).Delete(&post).Error
+	var ids []uint32
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+		if err := tx.Model(&post).Where("create_user_id = ?", userID).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		return tx.Where("create_user_id = ?", userID).Delete(&post).Error
+	})
 	if err != nil {
-		db.EndRollback()
+		return err
 	}
-	// トランザクションを終了しコミット
-	db.EndCommit()
-	return err
+	p.unindexPosts(ctx, ids...)
+	return nil
 }
 
 // GetByID IDを元に投稿を1件取得する
-func (p *PostInteractor) GetByID(ID uint32) (model.Post, error) {
-	DB := db.GetDB()
+func (p *PostInteractor) GetByID(ctx context.Context, ID uint32) (model.Post, error) {
+	var post model.Post
+	DB := db.Conn(ctx)
 	row := DB.First(&post, ID)
 	if err := row.Error; err != nil {
 		log.Printf("Error happend while Read for ID: %v\n", ID)
-		return model.Post{}, err
+		return model.Post{}, wrapNotFound(err, ResourcePost, ID)
 	}
 	DB.Table(db.PostTableName).Scan(row)
 	return post, nil
 }
 
-func getCommentByID(commentID uint32) (model.Comment, error) {
-	DB := db.GetDB()
+// GetCommentByID IDを元にゴミ箱に移動していないコメントを1件取得する
+func (p *PostInteractor) GetCommentByID(ctx context.Context, commentID uint32) (model.Comment, error) {
+	return getCommentByID(ctx, commentID)
+}
+
+func getCommentByID(ctx context.Context, commentID uint32) (model.Comment, error) {
+	var comment model.Comment
+	DB := db.Conn(ctx)
 	row := DB.First(&comment, commentID)
 	if err := row.Error; err != nil {
 		log.Printf("Error happend while Read for commentID: %v\n", commentID)
-		return model.Comment{}, err
+		return model.Comment{}, wrapNotFound(err, ResourceComment, commentID)
 	}
 	DB.Table(db.PostTableName).Scan(row)
 	return comment, nil
 }
 
 // GetJoinPostByID IDを元に投稿、紐付け情報を1件取得する
-func (p *PostInteractor) GetJoinPostByID(ID uint32) (model.JoinPost, error) {
-	post, err := p.GetByID(ID)
+// 閲覧ユーザーが参照できない投稿は存在しないものとして扱う
+func (p *PostInteractor) GetJoinPostByID(ctx context.Context, ID uint32, viewerID uint32) (model.JoinPost, error) {
+	post, err := p.GetByID(ctx, ID)
 
 	if err != nil {
 		log.Printf("Error happend while Read for ID: %v\n", ID)
 		return model.JoinPost{}, err
 	}
+	if !IsVisiblePost(&post, viewerID, time.Now()) {
+		return model.JoinPost{}, NewNotFoundError(ResourcePost, ID)
+	}
 
-	joinPost, err := createJoinPostSingle(post)
+	joinPost, err := p.createJoinPostSingle(ctx, post)
 	if err != nil {
 		log.Printf("Error happend while Read for ID: %v\n", ID)
 		return model.JoinPost{}, err
@@ -270,17 +287,38 @@ func (p *PostInteractor) GetJoinPostByID(ID uint32) (model.JoinPost, error) {
 }
 
 // Like 投稿のお気に入り
-func (p *PostInteractor) Like(postData *model.PostLikeUser) (*model.PostLikeUser, error) {
-	DB := db.GetDB()
-	if err := DB.Create(postData).Error; err != nil {
+// 同じユーザーは1つの投稿に1回のみお気に入りできる
+func (p *PostInteractor) Like(ctx context.Context, postData *model.PostLikeUser) (*model.PostLikeUser, error) {
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+		if err := checkPostExists(tx, postData.PostID); err != nil {
+			return err
+		}
+		var count int
+		if err := tx.Model(&model.PostLikeUser{}).Where("post_id = ? AND user_id = ?", postData.PostID, postData.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return NewAlreadyLikedError(postData.PostID)
+		}
+		// 同時にお気に入りした場合は、後から登録した方が主キー制約に違反する
+		if err := tx.Create(postData).Error; err != nil {
+			if isDuplicateEntry(err) {
+				return NewAlreadyLikedError(postData.PostID)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		return postData, err
 	}
 	return postData, nil
 }
 
 // NotLike 投稿のお気に入りの取り消し
-func (p *PostInteractor) NotLike(postData *model.PostLikeUser) (*model.PostLikeUser, error) {
-	DB := db.GetDB()
+func (p *PostInteractor) NotLike(ctx context.Context, postData *model.PostLikeUser) (*model.PostLikeUser, error) {
+	DB := db.Conn(ctx)
 	if err := DB.Where("post_id = ? ", postData.PostID).Where("user_id = ? ", postData.UserID).Delete(postData).Error; err != nil {
 		return postData, err
 	}
@@ -288,43 +326,59 @@ func (p *PostInteractor) NotLike(postData *model.PostLikeUser) (*model.PostLikeU
 }
 
 // CreateComment コメント作成
-func (p *PostInteractor) CreateComment(postData *model.Comment) (*model.Comment, error) {
-	validate = validator.New()
-	DB := db.GetDB()
-
-	if err := validate.Struct(postData); err != nil {
+// ゴミ箱に移動した投稿にはコメントできない
+func (p *PostInteractor) CreateComment(ctx context.Context, postData *model.Comment) (*model.Comment, error) {
+	if err := model.Validate(postData); err != nil {
 		log.Println("comment validation error", err)
 
 		return postData, err
 	}
+	postData.Version = 1
 
-	if err := DB.Create(postData).Error; err != nil {
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+		if err := checkPostExists(tx, postData.PostID); err != nil {
+			return err
+		}
+		return tx.Create(postData).Error
+	})
+	if err != nil {
 		return postData, err
 	}
 	return postData, nil
 }
 
 // UpdateComment コメント更新
-func (p *PostInteractor) UpdateComment(postData *model.Comment) (*model.Comment, error) {
-	validate = validator.New()
-	DB := db.GetDB()
-
-	if err := validate.Struct(postData); err != nil {
+func (p *PostInteractor) UpdateComment(ctx context.Context, postData *model.Comment) (*model.Comment, error) {
+	if err := model.Validate(postData); err != nil {
 		return postData, err
 	}
 
-	if err := DB.Save(postData).Error; err != nil {
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+
+		// 更新前の版数が一致する場合のみ更新する
+		if _, err := incrementVersion(tx, &model.Comment{}, ResourceComment, "comment_id", postData.CommentID, postData.Version); err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Comment{}).Where("comment_id = ?", postData.CommentID).Update("comment_content", postData.CommentContent).Error; err != nil {
+			return err
+		}
+		// 更新後のコメントを返す
+		return tx.Where("comment_id = ?", postData.CommentID).First(postData).Error
+	})
+	if err != nil {
 		return postData, err
 	}
 	return postData, nil
 }
 
-// DeleteComment 指定されたIDに対するコメント1件を削除
-func (p *PostInteractor) DeleteComment(id uint32) error {
+// DeleteComment 指定されたIDに対するコメント1件をゴミ箱に移動する
+func (p *PostInteractor) DeleteComment(ctx context.Context, id uint32) error {
 	var comment model.Comment
-	DB := db.GetDB()
+	DB := db.Conn(ctx)
 
-	// 指定されたPostIDのPostを削除
+	// 指定されたCommentIDのCommentを論理削除
 	if err := DB.Where("comment_id = ?", id).Delete(&comment).Error; err != nil {
 		return err
 	}
@@ -333,37 +387,12 @@ func (p *PostInteractor) DeleteComment(id uint32) error {
 }
 
 // listPostTagsByID PostIDを元にpostTagを検索し返す
-func listPostTagsByID(ID uint32) ([]model.PostTag, error) {
-	var postTagList []model.PostTag
-	DB := db.GetDB()
-	rows, err := DB.Where("post_tags.post_id = ?", ID).Find(&postTags).Joins("inner join tags on tags.id = post_tags.tag_id and tags.status = 1").Rows()
-	if err != nil {
-		log.Println("Error occured")
-		return nil, err
-	}
-	for rows.Next() {
-		DB.ScanRows(rows, &postTag)
-		postTagList = append(postTagList, postTag)
-	}
-
-	return postTagList, nil
-}
-
-// listCommentsByID PostIDを元にcommentを検索し返す
-func listCommentsByID(ID uint32) ([]model.Comment, error) {
-	var commentList []model.Comment
-	DB := db.GetDB()
-	rows, err := DB.Order("created_at").Where("post_id = ?", ID).Find(&comments).Rows()
+func listPostTagsByID(ctx context.Context, ID uint32) ([]model.PostTag, error) {
+	relations, err := listPostRelations(ctx, []uint32{ID})
 	if err != nil {
-		log.Println("Error occured")
 		return nil, err
 	}
-	for rows.Next() {
-		DB.ScanRows(rows, &comment)
-		commentList = append(commentList, comment)
-	}
-
-	return commentList, nil
+	return relations.PostTags[ID], nil
 }
 
 // listCommentsByUserID UserIDを元にcomment件数を検索し返す
@@ -375,72 +404,57 @@ func countCommentsByUserID(ID uint32) int {
 	return count
 }
 
-// listPostLikeUsersByID PostIDを元にお気に入りしているユーザーを検索し返す
-func listPostLikeUsersByID(ID uint32) ([]model.PostLikeUser, error) {
-	var postLikeUserList []model.PostLikeUser
-	DB := db.GetDB()
-	rows, err := DB.Where("post_id = ?", ID).Find(&likeUsers).Rows()
-	if err != nil {
-		log.Println("Error occured")
-		return nil, err
-	}
-	for rows.Next() {
-		DB.ScanRows(rows, &likeUser)
-		postLikeUserList = append(postLikeUserList, likeUser)
-	}
-
-	return postLikeUserList, nil
-}
-
-func createJoinPosts(posts []model.Post) ([]model.JoinPost, error) {
-	var joinPosts []model.JoinPost
-
+func (p *PostInteractor) createJoinPosts(ctx context.Context, posts []model.Post) ([]model.JoinPost, error) {
 	if len(posts) == 0 {
 		log.Println("post is nil")
+		return []model.JoinPost{}, nil
+	}
+
+	// 紐付けられているタグ、Likeしているユーザー、コメントを一括で取得
+	// タグ名はフロント側で保持しているタグストアから取得する
+	postIDs := make([]uint32, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	relations, err := listPostRelations(ctx, postIDs)
+	if err != nil {
 		return []model.JoinPost{}, err
 	}
 
-	// 全ユーザーをUserServiceから取得
-	users := getUserData()
+	// 投稿者、Likeしているユーザー、コメントしたユーザーの情報を取得
+	users := LookupUsers(ctx, p.Users, posts, relations)
 
+	return MakeJoinPosts(posts, relations, users), nil
+}
+
+// MakeJoinPosts 投稿ごとに紐付け情報、ユーザー情報を付与する
+func MakeJoinPosts(posts []model.Post, relations PostRelations, users map[uint32]model.User) []model.JoinPost {
+	joinPosts := []model.JoinPost{}
 	for _, post := range posts {
 		var likeUsers []model.User
 		var joinComments []model.JoinComment
 		// 投稿者のユーザー情報
 		createUser := users[post.CreateUserID]
 
-		// 紐付けられているタグ情報
-		// タグ名はフロント側で保持しているタグストアから取得する
-		postTags, err := listPostTagsByID(post.ID)
-		if err != nil {
-			return []model.JoinPost{}, err
-		}
-		// Likeしているユーザー
-		// post.IDより取得
-		postLikeUsers, err := listPostLikeUsersByID(post.ID)
-		for _, user := range postLikeUsers {
+		for _, user := range relations.PostLikeUsers[post.ID] {
 			likeUsers = append(likeUsers, users[user.UserID])
 		}
 
-		// コメントをpost.IDより取得
-		comments, err := listCommentsByID(post.ID)
-		for _, comment := range comments {
+		for _, comment := range relations.Comments[post.ID] {
 			createUser := users[comment.CreateUserID]
 			joinComment := model.JoinComment{Comment: comment, CreateUser: createUser}
 			joinComments = append(joinComments, joinComment)
 		}
-		// post.IDより取得
-		joinPost := makeJoinPost(post, createUser, postTags, likeUsers, joinComments)
+		joinPost := makeJoinPost(post, createUser, relations.PostTags[post.ID], likeUsers, joinComments)
 		joinPosts = append(joinPosts, joinPost)
 	}
-
-	return joinPosts, nil
+	return joinPosts
 }
 
 // 単一投稿のJoinPostを返す
-func createJoinPostSingle(post model.Post) (model.JoinPost, error) {
+func (p *PostInteractor) createJoinPostSingle(ctx context.Context, post model.Post) (model.JoinPost, error) {
 	posts := []model.Post{post}
-	joinPost, err := createJoinPosts(posts)
+	joinPost, err := p.createJoinPosts(ctx, posts)
 
 	return joinPost[0], err
 }
@@ -497,48 +511,28 @@ func countCommentByPostID(ID uint32) int {
 	return count
 }
 
-func deletePostTagByPostID(ID uint32) {
-	DB := db.GetDB()
-	DB.Where("post_id = ?", ID).Delete(&postTags)
-}
-
-// ユーザーサービスからユーザー情報取得
-func getUserData() map[uint32]model.User {
-	userURL := os.Getenv("USER_URL")
-	cc, err := grpc.Dial(userURL, grpc.WithInsecure())
-	if err != nil {
-		log.Fatalf("could not connect: %v", err)
-	}
-
-	defer cc.Close()
-	userClient := userservice.NewUserServiceClient(cc)
-
-	request := &userservice.ListUserRequest{}
-	res, err := userClient.ListUser(context.Background(), request)
-	if err != nil {
-		panic(err)
+// checkPostExists ゴミ箱に移動していない投稿が存在するか確認する
+func checkPostExists(tx *gorm.DB, ID uint32) error {
+	var count int
+	if err := tx.Model(&model.Post{}).Where("id = ?", ID).Count(&count).Error; err != nil {
+		return err
 	}
-
-	resUsers := res.GetProfile()
-	var users map[uint32]model.User
-	users = map[uint32]model.User{}
-	for _, user := range resUsers {
-		id := user.UserId
-
-		users[id] = model.User{
-			ID:       user.UserId,
-			UserName: user.UserName,
-		}
+	if count == 0 {
+		return NewNotFoundError(ResourcePost, ID)
 	}
-	return users
+	return nil
+}
 
+func deletePostTagByPostID(ctx context.Context, ID uint32) error {
+	DB := db.Conn(ctx)
+	return DB.Where("post_id = ?", ID).Delete(&model.PostTag{}).Error
 }
 
-// DeleteCommentsByUserID 退会したユーザーIDを元にコメントを削除する
-func (p *PostInteractor) DeleteCommentsByUserID(userID uint32) error {
+// DeleteCommentsByUserID 退会したユーザーIDを元にコメントをゴミ箱に移動する
+func (p *PostInteractor) DeleteCommentsByUserID(ctx context.Context, userID uint32) error {
 	var comment model.Comment
 
-	DB := db.GetDB()
+	DB := db.Conn(ctx)
 	err := DB.Where("create_user_id = ?", userID).Delete(&comment).Error
 	return err
 }
@@ -0,0 +1,132 @@
+package interactor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+const (
+	// defaultPageSize ページサイズ未指定時の取得件数
+	defaultPageSize uint32 = 20
+	// maxPageSize 1ページで取得できる最大件数
+	maxPageSize uint32 = 100
+)
+
+// ErrInvalidPageToken ページトークンが不正な時のエラー
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PostCursor 投稿一覧のページ位置
+// (created_at, id) の組で位置を表すため、新規投稿が追加されてもページがずれない
+type PostCursor struct {
+	CreatedAt int64  `json:"c"`
+	ID        uint32 `json:"i"`
+	// 並び順(作成日時の新しい順の場合は省略)
+	Sort model.PostSort `json:"s,omitempty"`
+	// 並び替えの値(いいね数、コメント数、トレンドスコア)
+	Key int64 `json:"k,omitempty"`
+	// トレンドスコアの基準日時
+	Now int64 `json:"n,omitempty"`
+}
+
+// encodePostCursor 投稿の位置を不透明なトークンに変換する
+func encodePostCursor(post model.Post) string {
+	b, _ := json.Marshal(PostCursor{
+		CreatedAt: post.CreatedAt.UnixNano(),
+		ID:        post.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// EncodeSortedPostCursor 並び順と並び替えの値を含めて投稿の位置をトークンに変換する
+func EncodeSortedPostCursor(post model.Post, sort model.PostSort, key int64, now time.Time) string {
+	cursor := PostCursor{
+		CreatedAt: post.CreatedAt.UnixNano(),
+		ID:        post.ID,
+		Sort:      sort,
+		Key:       key,
+	}
+	if sort == model.PostSortTrending {
+		cursor.Now = now.Unix()
+	}
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodePostCursor トークンを投稿の位置に変換する。空のトークンはnilを返す
+func DecodePostCursor(token string) (*PostCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var cursor PostCursor
+	if err := json.Unmarshal(b, &cursor); err != nil || cursor.ID == 0 {
+		return nil, ErrInvalidPageToken
+	}
+	return &cursor, nil
+}
+
+// searchCursor 全文検索結果のページ位置
+// 関連度順の結果は (created_at, id) で位置を表せないため、先頭からの件数で表す
+type searchCursor struct {
+	Offset uint32 `json:"o"`
+}
+
+// encodeSearchCursor 全文検索結果の位置を不透明なトークンに変換する
+func encodeSearchCursor(offset uint32) string {
+	b, _ := json.Marshal(searchCursor{Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeSearchCursor トークンを全文検索結果の位置に変換する。空のトークンは0を返す
+func DecodeSearchCursor(token string) (uint32, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+	var cursor searchCursor
+	if err := json.Unmarshal(b, &cursor); err != nil || cursor.Offset == 0 {
+		return 0, ErrInvalidPageToken
+	}
+	return cursor.Offset, nil
+}
+
+// NormalizePageSize ページサイズをデフォルト値、上限値に丸める
+func NormalizePageSize(size uint32) uint32 {
+	if size == 0 {
+		return defaultPageSize
+	}
+	if size > maxPageSize {
+		return maxPageSize
+	}
+	return size
+}
+
+// paginatePosts カーソル位置より後ろの投稿をsize+1件取得するクエリを返す
+// 1件多く取得することで次ページの有無を判定する
+func paginatePosts(query *gorm.DB, cursor *PostCursor, size uint32) *gorm.DB {
+	if cursor != nil {
+		createdAt := time.Unix(0, cursor.CreatedAt)
+		query = query.Where("posts.created_at < ? OR (posts.created_at = ? AND posts.id < ?)", createdAt, createdAt, cursor.ID)
+	}
+	return query.Order("posts.created_at desc").Order("posts.id desc").Limit(size + 1)
+}
+
+// SplitPage 取得した投稿を1ページ分に切り詰め、次ページのトークンを返す
+func SplitPage(posts []model.Post, size uint32) ([]model.Post, string) {
+	if uint32(len(posts)) <= size {
+		return posts, ""
+	}
+	posts = posts[:size]
+	return posts, encodePostCursor(posts[len(posts)-1])
+}
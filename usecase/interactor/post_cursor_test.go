@@ -0,0 +1,95 @@
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// TestPostCursorRoundTrip トークンから元の位置が復元できる事をテスト
+func TestPostCursorRoundTrip(t *testing.T) {
+	post := model.Post{ID: 12, CreatedAt: time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)}
+	token := encodePostCursor(post)
+
+	cursor, err := DecodePostCursor(token)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, post.ID, cursor.ID)
+	assert.Equal(t, post.CreatedAt.UnixNano(), cursor.CreatedAt)
+}
+
+// TestSortedPostCursorRoundTrip 並び順と並び替えの値がトークンから復元できる事をテスト
+func TestSortedPostCursorRoundTrip(t *testing.T) {
+	post := model.Post{ID: 12, CreatedAt: time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)}
+	now := time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	cursor, err := DecodePostCursor(EncodeSortedPostCursor(post, model.PostSortTrending, 42, now))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.PostSortTrending, cursor.Sort)
+	assert.Equal(t, int64(42), cursor.Key)
+	assert.Equal(t, now.Unix(), cursor.Now)
+
+	// トレンド以外の並び順では基準日時を持たない
+	cursor, err = DecodePostCursor(EncodeSortedPostCursor(post, model.PostSortMostLikes, 3, now))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(0), cursor.Now)
+}
+
+// TestValidatePostSort 別の並び順のトークンが不正になる事をテスト
+func TestValidatePostSort(t *testing.T) {
+	assert.Equal(t, nil, ValidatePostSort(model.PostSortTrending, nil))
+	assert.Equal(t, ErrInvalidPostSort, ValidatePostSort(model.PostSortTrending+1, nil))
+
+	cursor := &PostCursor{ID: 1, Sort: model.PostSortMostLikes}
+	assert.Equal(t, nil, ValidatePostSort(model.PostSortMostLikes, cursor))
+	assert.Equal(t, ErrInvalidPageToken, ValidatePostSort(model.PostSortNewest, cursor))
+}
+
+// TestDecodePostCursorInvalid 不正なトークンがエラーになる事をテスト
+func TestDecodePostCursorInvalid(t *testing.T) {
+	for _, token := range []string{"!!!", "bm90IGpzb24", "e30"} {
+		_, err := DecodePostCursor(token)
+		assert.Equal(t, ErrInvalidPageToken, err)
+	}
+
+	cursor, err := DecodePostCursor("")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, cursor == nil)
+}
+
+// TestSplitPage 1件多く取得した時のみ次ページのトークンが返る事をテスト
+func TestSplitPage(t *testing.T) {
+	posts := []model.Post{{ID: 3}, {ID: 2}, {ID: 1}}
+
+	page, token := SplitPage(posts, 3)
+	assert.Equal(t, 3, len(page))
+	assert.Equal(t, "", token)
+
+	page, token = SplitPage(posts, 2)
+	assert.Equal(t, 2, len(page))
+	cursor, err := DecodePostCursor(token)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, two, cursor.ID)
+}
+
+// TestNormalizePageSize ページサイズの丸めをテスト
+func TestNormalizePageSize(t *testing.T) {
+	assert.Equal(t, defaultPageSize, NormalizePageSize(0))
+	assert.Equal(t, one, NormalizePageSize(1))
+	assert.Equal(t, maxPageSize, NormalizePageSize(maxPageSize+1))
+}
+
+// TestSearchCursorRoundTrip 全文検索結果の位置が復元できる事をテスト
+func TestSearchCursorRoundTrip(t *testing.T) {
+	offset, err := DecodeSearchCursor(encodeSearchCursor(40))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint32(40), offset)
+
+	offset, err = DecodeSearchCursor("")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, zero, offset)
+
+	_, err = DecodeSearchCursor("!!!")
+	assert.Equal(t, ErrInvalidPageToken, err)
+}
@@ -0,0 +1,138 @@
+package interactor
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+var (
+	// ErrInvalidListCondition 存在しない一覧取得条件が指定された時のエラー
+	ErrInvalidListCondition = errors.New("invalid list condition")
+	// ErrInvalidTagMatch 存在しない複数タグ指定時の条件が指定された時のエラー
+	ErrInvalidTagMatch = errors.New("invalid tag match")
+	// ErrInvalidImageFilter 存在しない画像の有無の条件が指定された時のエラー
+	ErrInvalidImageFilter = errors.New("invalid image filter")
+	// ErrInvalidCreatedAtRange 作成日時の範囲が不正な時のエラー
+	ErrInvalidCreatedAtRange = errors.New("created_after must be before created_before")
+	// ErrInvalidCreatedAt 作成日時の条件が日時として不正な時のエラー
+	ErrInvalidCreatedAt = errors.New("invalid created_after or created_before")
+	// ErrInvalidCommentCountRange コメント数の範囲が不正な時のエラー
+	ErrInvalidCommentCountRange = errors.New("min_comment_count must not exceed max_comment_count")
+)
+
+// ListConditionFilter 一覧取得条件(create, like, tag)を絞り込み条件に変換し、filterと合成する
+// 条件が空の場合はfilterをそのまま返す
+func ListConditionFilter(condition model.PostListCondition) (model.PostFilter, error) {
+	filter := condition.Filter
+	id := condition.ID
+
+	switch condition.Condition {
+	case "":
+	case "create":
+		filter.CreateUserIDs = intersectIDs(filter.CreateUserIDs, id)
+	case "like":
+		if filter.LikedByUserID != 0 && filter.LikedByUserID != id {
+			// いいねしたユーザーは1人のみ指定できる
+			return filter, ErrInvalidListCondition
+		}
+		filter.LikedByUserID = id
+	case "tag":
+		if len(filter.TagIDs) > 0 && filter.TagMatch == model.TagMatchAny {
+			filter.TagIDs = intersectIDs(filter.TagIDs, id)
+			break
+		}
+		filter.TagIDs = append(filter.TagIDs, id)
+	default:
+		return filter, ErrInvalidListCondition
+	}
+	return filter, nil
+}
+
+// intersectIDs いずれかに一致する条件のIDリストをidで更に絞り込む
+// 空のリストは指定なしとして扱う
+func intersectIDs(ids []uint32, id uint32) []uint32 {
+	if len(ids) == 0 {
+		return []uint32{id}
+	}
+	for _, v := range ids {
+		if v == id {
+			return []uint32{id}
+		}
+	}
+	// 一致するIDが存在しないため、どの投稿にも一致しない条件にする
+	return []uint32{0}
+}
+
+// ValidatePostFilter 絞り込み条件の値を検証する
+func ValidatePostFilter(filter model.PostFilter) error {
+	switch filter.TagMatch {
+	case model.TagMatchAny, model.TagMatchAll:
+	default:
+		return ErrInvalidTagMatch
+	}
+	switch filter.Image {
+	case model.ImageAny, model.ImageWith, model.ImageWithout:
+	default:
+		return ErrInvalidImageFilter
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return ErrInvalidCreatedAtRange
+	}
+	if filter.MaxCommentCount != nil && filter.MinCommentCount > *filter.MaxCommentCount {
+		return ErrInvalidCommentCountRange
+	}
+	return nil
+}
+
+// filterPosts 絞り込み条件を投稿取得クエリに追加する
+// 紐付けテーブルはサブクエリで参照し、1投稿が重複して取得されないようにする
+func filterPosts(query *gorm.DB, filter model.PostFilter) *gorm.DB {
+	if len(filter.CreateUserIDs) > 0 {
+		query = query.Where("posts.create_user_id IN (?)", filter.CreateUserIDs)
+	}
+	if tagIDs := UniqueIDs(filter.TagIDs); len(tagIDs) > 0 {
+		if filter.TagMatch == model.TagMatchAll {
+			query = query.Where("(SELECT COUNT(DISTINCT post_tags.tag_id) FROM post_tags WHERE post_tags.post_id = posts.id AND post_tags.tag_id IN (?)) = ?", tagIDs, len(tagIDs))
+		} else {
+			query = query.Where("EXISTS (SELECT 1 FROM post_tags WHERE post_tags.post_id = posts.id AND post_tags.tag_id IN (?))", tagIDs)
+		}
+	}
+	if filter.LikedByUserID != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM post_like_users WHERE post_like_users.post_id = posts.id AND post_like_users.user_id = ?)", filter.LikedByUserID)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("posts.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("posts.created_at < ?", *filter.CreatedBefore)
+	}
+	switch filter.Image {
+	case model.ImageWith:
+		query = query.Where("posts.image IS NOT NULL AND posts.image <> ''")
+	case model.ImageWithout:
+		query = query.Where("posts.image IS NULL OR posts.image = ''")
+	}
+	if filter.MinCommentCount > 0 {
+		query = query.Where(commentCountQuery+" >= ?", filter.MinCommentCount)
+	}
+	if filter.MaxCommentCount != nil {
+		query = query.Where(commentCountQuery+" <= ?", *filter.MaxCommentCount)
+	}
+	return query
+}
+
+// UniqueIDs 重複を除いたIDのリストを返す
+func UniqueIDs(ids []uint32) []uint32 {
+	seen := make(map[uint32]bool, len(ids))
+	var unique []uint32
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
@@ -0,0 +1,57 @@
+package interactor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// TestListConditionFilter 一覧取得条件が絞り込み条件に合成される事をテスト
+func TestListConditionFilter(t *testing.T) {
+	tests := []struct {
+		condition model.PostListCondition
+		want      model.PostFilter
+		err       error
+	}{
+		{model.PostListCondition{}, model.PostFilter{}, nil},
+		{model.PostListCondition{Condition: "create", ID: 1}, model.PostFilter{CreateUserIDs: []uint32{1}}, nil},
+		{model.PostListCondition{Condition: "create", ID: 1, Filter: model.PostFilter{CreateUserIDs: []uint32{1, 2}}}, model.PostFilter{CreateUserIDs: []uint32{1}}, nil},
+		{model.PostListCondition{Condition: "create", ID: 3, Filter: model.PostFilter{CreateUserIDs: []uint32{1, 2}}}, model.PostFilter{CreateUserIDs: []uint32{0}}, nil},
+		{model.PostListCondition{Condition: "like", ID: 1}, model.PostFilter{LikedByUserID: 1}, nil},
+		{model.PostListCondition{Condition: "like", ID: 1, Filter: model.PostFilter{LikedByUserID: 2}}, model.PostFilter{LikedByUserID: 2}, ErrInvalidListCondition},
+		{model.PostListCondition{Condition: "tag", ID: 1}, model.PostFilter{TagIDs: []uint32{1}}, nil},
+		{model.PostListCondition{Condition: "tag", ID: 1, Filter: model.PostFilter{TagIDs: []uint32{2}, TagMatch: model.TagMatchAll}}, model.PostFilter{TagIDs: []uint32{2, 1}, TagMatch: model.TagMatchAll}, nil},
+		{model.PostListCondition{Condition: "unknown", ID: 1}, model.PostFilter{}, ErrInvalidListCondition},
+	}
+	for _, test := range tests {
+		filter, err := ListConditionFilter(test.condition)
+		assert.Equal(t, test.err, err)
+		if err == nil {
+			assert.Equal(t, test.want, filter)
+		}
+	}
+}
+
+// TestValidatePostFilter 不正な絞り込み条件がエラーになる事をテスト
+func TestValidatePostFilter(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	zeroCount := uint32(0)
+	tests := []struct {
+		filter model.PostFilter
+		want   error
+	}{
+		{model.PostFilter{}, nil},
+		{model.PostFilter{CreatedAfter: &earlier, CreatedBefore: &now, MaxCommentCount: &zeroCount}, nil},
+		{model.PostFilter{TagMatch: 2}, ErrInvalidTagMatch},
+		{model.PostFilter{Image: 3}, ErrInvalidImageFilter},
+		{model.PostFilter{CreatedAfter: &now, CreatedBefore: &earlier}, ErrInvalidCreatedAtRange},
+		{model.PostFilter{CreatedAfter: &now, CreatedBefore: &now}, ErrInvalidCreatedAtRange},
+		{model.PostFilter{MinCommentCount: 1, MaxCommentCount: &zeroCount}, ErrInvalidCommentCountRange},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, ValidatePostFilter(test.filter))
+	}
+}
@@ -0,0 +1,67 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// ErrAlreadyLiked 既にお気に入りした投稿をお気に入りしようとした時のエラー
+var ErrAlreadyLiked = errors.New("already liked")
+
+// integrityCheck 参照先が存在しない紐付け情報を探す条件
+type integrityCheck struct {
+	table     string
+	reference string
+	// 参照先が存在しない行の条件
+	condition string
+}
+
+// integrityChecks 検査する紐付け情報の一覧
+// ゴミ箱に移動した投稿は復元できるため、参照先として存在するものとして扱う
+// 1つの行は最初に見つかった参照先でのみ数え、投稿とタグの両方が存在しない紐付けはpostsの件数とする。
+// 参照先のない更新履歴のタグは、更新履歴と一緒に削除するため件数に含める
+var integrityChecks = []integrityCheck{
+	{"post_tags", "posts", "post_id NOT IN (SELECT id FROM posts)"},
+	{"post_tags", "tags", "tag_id NOT IN (SELECT id FROM tags) AND post_id IN (SELECT id FROM posts)"},
+	{"post_like_users", "posts", "post_id NOT IN (SELECT id FROM posts)"},
+	{"comments", "posts", "post_id NOT IN (SELECT id FROM posts)"},
+	{"post_revisions", "posts", "post_id NOT IN (SELECT id FROM posts)"},
+	{"post_revision_tags", "post_revisions", "revision_id NOT IN (SELECT id FROM post_revisions WHERE post_id IN (SELECT id FROM posts))"},
+}
+
+// CheckIntegrity 参照先が存在しない紐付け情報を検査し、見つかった件数を返す
+// repairがtrueの場合は、見つかった紐付け情報を1つのトランザクションで削除する
+func (p *PostInteractor) CheckIntegrity(ctx context.Context, repair bool) ([]model.IntegrityIssue, error) {
+	var issues []model.IntegrityIssue
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+		issues = nil
+		for _, check := range integrityChecks {
+			var count int64
+			if err := tx.Table(check.table).Where(check.condition).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				continue
+			}
+			issue := model.IntegrityIssue{Table: check.table, Reference: check.reference, Count: count}
+			if repair {
+				query := fmt.Sprintf("DELETE FROM %s WHERE %s", check.table, check.condition)
+				if err := tx.Exec(query).Error; err != nil {
+					return err
+				}
+				issue.Repaired = true
+			}
+			issues = append(issues, issue)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
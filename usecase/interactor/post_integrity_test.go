@@ -0,0 +1,90 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// orphanPostID 紐付け情報の参照先に使う存在しない投稿ID
+const orphanPostID uint32 = 100000
+
+// uncheckedInserter 参照先を検査せずに紐付け情報を登録できるリポジトリ
+type uncheckedInserter interface {
+	InsertUnchecked(values ...interface{}) error
+}
+
+// uncheckedPostInteractor 外部キー制約を無効にして紐付け情報を登録できるPostInteractor
+type uncheckedPostInteractor struct {
+	*PostInteractor
+}
+
+// InsertUnchecked 外部キー制約を無効にした接続で紐付け情報を登録する
+func (p *uncheckedPostInteractor) InsertUnchecked(values ...interface{}) error {
+	return withoutForeignKeys(func(tx *gorm.DB) error {
+		for _, value := range values {
+			if err := tx.Create(value).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// testCheckIntegrity 検査では削除せず、修復後は参照先のない紐付け情報が残らない事をテスト
+func testCheckIntegrity(t *testing.T, posts repository.PostRepository, expected map[string]int64) {
+	ctx := context.Background()
+	issues, err := posts.CheckIntegrity(ctx, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expected, integrityIssueCounts(issues))
+	for _, issue := range issues {
+		assert.Equal(t, false, issue.Repaired)
+	}
+
+	// 検査のみの場合は削除しない
+	issues, err = posts.CheckIntegrity(ctx, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, len(expected), len(issues))
+
+	issues, err = posts.CheckIntegrity(ctx, true)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, expected, integrityIssueCounts(issues))
+	for _, issue := range issues {
+		assert.Equal(t, true, issue.Repaired)
+	}
+
+	issues, err = posts.CheckIntegrity(ctx, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(issues))
+}
+
+// integrityIssueCounts 検査結果を「テーブル名/参照先」ごとの件数にする
+func integrityIssueCounts(issues []model.IntegrityIssue) map[string]int64 {
+	counts := map[string]int64{}
+	for _, issue := range issues {
+		counts[issue.Table+"/"+issue.Reference] = issue.Count
+	}
+	return counts
+}
+
+// withoutForeignKeys 外部キー制約を無効にした接続でfnを実行する
+func withoutForeignKeys(fn func(tx *gorm.DB) error) error {
+	if db.IsSQLite() {
+		// SQLiteは接続を1つに絞っているため、同じ接続で実行される
+		DB := db.GetDB()
+		DB.Exec("PRAGMA foreign_keys = OFF")
+		defer DB.Exec("PRAGMA foreign_keys = ON")
+		return fn(DB)
+	}
+	return db.Transaction(context.Background(), func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+		tx.Exec("SET FOREIGN_KEY_CHECKS = 0")
+		defer tx.Exec("SET FOREIGN_KEY_CHECKS = 1")
+		return fn(tx)
+	})
+}
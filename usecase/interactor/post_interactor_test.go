@@ -2,6 +2,7 @@ package interactor
 
 import (
 	"context"
+	"errors"
 	"log"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/go-playground/assert/v2"
 	"github.com/yzmw1213/PostService/db"
 	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/search"
 )
 
 var (
@@ -24,6 +26,9 @@ var (
 	user3               uint32
 )
 
+// testTagCount テスト開始時に登録しておくタグの件数
+const testTagCount uint32 = 8
+
 // TestCreate 投稿作成の正常系
 func TestCreate(t *testing.T) {
 	initTable()
@@ -35,7 +40,7 @@ func TestCreate(t *testing.T) {
 	//
 	joinPost := makeJoinPost(post, DemoUser, postTags, nil, nil)
 
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 
 	assert.Equal(t, nil, err)
 	assert.Equal(t, post.CreateUserID, createdPost.Post.CreateUserID)
@@ -44,7 +49,7 @@ func TestCreate(t *testing.T) {
 
 	// Postと同一PostIDのPostTagが登録されている事を確認
 	postID := createdPost.Post.ID
-	postTags, err = listPostTagsByID(postID)
+	postTags, err = listPostTagsByID(context.Background(), postID)
 
 	// 登録後のpostTag登録数
 	afterPostTagCount := countPostTagByID(postID)
@@ -59,7 +64,7 @@ func TestCreateContentNull(t *testing.T) {
 	joinPost := makeJoinPost(post, DemoUser, postTags, nil, nil)
 
 	beforePostTagCount := countPostTag()
-	_, err := i.Create(&joinPost)
+	_, err := i.Create(context.Background(), &joinPost)
 	// PostTagがInsertされていない事をテスト
 	afterPostTagCount := countPostTag()
 	assert.Equal(t, beforePostTagCount, afterPostTagCount)
@@ -75,7 +80,7 @@ func TestCreateContentTooLong(t *testing.T) {
 
 	beforePostTagCount := countPostTag()
 
-	_, err := i.Create(&joinPost)
+	_, err := i.Create(context.Background(), &joinPost)
 	afterPostTagCount := countPostTag()
 
 	assert.Equal(t, beforePostTagCount, afterPostTagCount)
@@ -90,7 +95,7 @@ func TestCreateTitleNull(t *testing.T) {
 
 	beforePostTagCount := countPostTag()
 
-	_, err := i.Create(&joinPost)
+	_, err := i.Create(context.Background(), &joinPost)
 	afterPostTagCount := countPostTag()
 
 	assert.NotEqual(t, nil, err)
@@ -104,7 +109,7 @@ func TestCreateTitleTooLong(t *testing.T) {
 	joinPost := makeJoinPost(post, DemoUser, postTags, nil, nil)
 	beforePostTagCount := countPostTag()
 
-	_, err := i.Create(&joinPost)
+	_, err := i.Create(context.Background(), &joinPost)
 	afterPostTagCount := countPostTag()
 
 	assert.NotEqual(t, nil, err)
@@ -118,30 +123,108 @@ func TestDelete(t *testing.T) {
 	postTags := makePostTags()
 	joinPost := makeJoinPost(post, DemoUser, postTags, nil, nil)
 
-	cretedJoinPost, err := i.Create(&joinPost)
+	cretedJoinPost, err := i.Create(context.Background(), &joinPost)
 
 	assert.Equal(t, nil, err)
 	postID := cretedJoinPost.Post.ID
 	beforePostTagCount := countPostTag()
 
-	err = i.DeleteByID(postID)
+	err = i.DeleteByID(context.Background(), postID)
 	assert.Equal(t, nil, err)
 
-	deletedPost, err := i.GetByID(postID)
+	deletedPost, err := i.GetByID(context.Background(), postID)
 	assert.NotEqual(t, nil, err)
 	assert.Equal(t, zero, deletedPost.ID)
 	assert.Equal(t, zero, deletedPost.CreateUserID)
 	assert.Equal(t, "", deletedPost.Title)
 	assert.Equal(t, "", deletedPost.Content)
 
-	// Postと同一IDのPostTagが全て削除されている事を確認
-	postTags, err = listPostTagsByID(postID)
+	// 復元できるようPostTagは削除されていない事を確認
 	afterPostTagCount := countPostTag()
+	assert.Equal(t, beforePostTagCount, afterPostTagCount)
+}
+
+// TestRestorePost ゴミ箱に移動した投稿の復元
+func TestRestorePost(t *testing.T) {
+	var i PostInteractor
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user2
+	joinPost := makeJoinPost(post, DemoUser, makePostTags(), nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+	postID := createdPost.Post.ID
+
+	err = i.DeleteByID(context.Background(), postID)
+	assert.Equal(t, nil, err)
+
+	trashed, _, err := i.ListTrashed(context.Background(), user2, model.Pagination{PageSize: maxPageSize})
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, 0, len(trashed))
+
+	err = i.Restore(context.Background(), postID)
+	assert.Equal(t, nil, err)
+	restoredPost, err := i.GetJoinPostByID(context.Background(), postID, user2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(restoredPost.PostTags))
+
+	// ゴミ箱にない投稿は復元できない
+	err = i.Restore(context.Background(), postID)
+	assert.NotEqual(t, nil, err)
+}
+
+// TestPurgePost ゴミ箱に移動した投稿が紐付け情報と共に完全削除される事をテスト
+func TestPurgePost(t *testing.T) {
+	var i PostInteractor
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user3
+	joinPost := makeJoinPost(post, DemoUser, makePostTags(), nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+	postID := createdPost.Post.ID
+	_, err = i.Like(context.Background(), &model.PostLikeUser{PostID: postID, UserID: user1})
+	assert.Equal(t, nil, err)
+	comment := makeComment(*createdPost.Post, testCommentContent)
+	_, err = i.CreateComment(context.Background(), &comment)
 	assert.Equal(t, nil, err)
-	assert.Equal(t, 0, len(postTags))
-	assert.NotEqual(t, beforePostTagCount, afterPostTagCount)
 
-	// Postと同一IDCommentが削除されていない事を確認
+	// ゴミ箱にない投稿は完全削除できない
+	err = i.Purge(context.Background(), postID)
+	assert.NotEqual(t, nil, err)
+
+	err = i.DeleteByID(context.Background(), postID)
+	assert.Equal(t, nil, err)
+	err = i.Purge(context.Background(), postID)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 0, countPostTagByPostID(postID))
+	assert.Equal(t, 0, countPostLikeUserByPostID(postID))
+	assert.Equal(t, 0, countCommentByPostIDUnscoped(postID))
+	err = i.Restore(context.Background(), postID)
+	assert.NotEqual(t, nil, err)
+}
+
+// TestPurgeTrashed 保持期間を過ぎた投稿のみ完全削除される事をテスト
+func TestPurgeTrashed(t *testing.T) {
+	var i PostInteractor
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user1
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+	postID := createdPost.Post.ID
+	err = i.DeleteByID(context.Background(), postID)
+	assert.Equal(t, nil, err)
+
+	_, err = i.PurgeTrashed(context.Background(), time.Now().AddDate(0, 0, -1))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, i.Restore(context.Background(), postID))
+
+	err = i.DeleteByID(context.Background(), postID)
+	assert.Equal(t, nil, err)
+	count, err := i.PurgeTrashed(context.Background(), time.Now().Add(time.Second))
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, int64(0), count)
+	assert.NotEqual(t, nil, i.Restore(context.Background(), postID))
 }
 
 func TestUpdatePost(t *testing.T) {
@@ -149,7 +232,7 @@ func TestUpdatePost(t *testing.T) {
 	post := makePost(testTitle, testContent)
 	post.CreateUserID = user2
 	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
-	createdJoinPost, err := i.Create(&joinPost)
+	createdJoinPost, err := i.Create(context.Background(), &joinPost)
 
 	assert.Equal(t, nil, err)
 	updateJoinPost := createdJoinPost
@@ -157,11 +240,11 @@ func TestUpdatePost(t *testing.T) {
 	updateJoinPost.Post.UpdateUserID = user2
 
 	time.Sleep(time.Second * 3)
-	updatedJoinPost, err := i.Update(updateJoinPost)
+	updatedJoinPost, err := i.Update(context.Background(), updateJoinPost)
 	updatedPost := updatedJoinPost.Post
 
 	assert.Equal(t, nil, err)
-	readPost, err := i.GetByID(updatedJoinPost.Post.ID)
+	readPost, err := i.GetByID(context.Background(), updatedJoinPost.Post.ID)
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, "content", updatedPost.Content)
 	assert.Equal(t, createdJoinPost.Post.ID, updatedPost.ID)
@@ -171,6 +254,34 @@ func TestUpdatePost(t *testing.T) {
 	assert.Equal(t, user2, updatedPost.UpdateUserID)
 }
 
+// TestUpdatePostVersionConflict 古い版数での更新が失敗する事をテスト
+func TestUpdatePostVersionConflict(t *testing.T) {
+	var i PostInteractor
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user1
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, one, createdPost.Post.Version)
+	postID := createdPost.Post.ID
+
+	// 同じ版数を読み込んだ2人の編集者
+	first := makeJoinPost(model.Post{ID: postID, Title: "first", Content: testContent, CreateUserID: user1, UpdateUserID: user1, Version: one}, DemoUser, nil, nil, nil)
+	second := makeJoinPost(model.Post{ID: postID, Title: "second", Content: testContent, CreateUserID: user1, UpdateUserID: user2, Version: one}, DemoUser, nil, nil, nil)
+
+	updatedPost, err := i.Update(context.Background(), &first)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, two, updatedPost.Post.Version)
+
+	_, err = i.Update(context.Background(), &second)
+	assert.Equal(t, true, errors.Is(err, ErrVersionConflict))
+
+	readPost, err := i.GetByID(context.Background(), postID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "first", readPost.Title)
+	assert.Equal(t, two, readPost.Version)
+}
+
 func TestUpdatePostTag(t *testing.T) {
 	var i PostInteractor
 	post := makePost(testTitle, testContent)
@@ -178,7 +289,7 @@ func TestUpdatePostTag(t *testing.T) {
 	post.CreateUserID = user3
 	joinPost := makeJoinPost(post, DemoUser, postTags, nil, nil)
 
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 	assert.Equal(t, nil, err)
 	postID := createdPost.Post.ID
 	beforePostTagCount := countPostTagByPostID(postID)
@@ -189,7 +300,7 @@ func TestUpdatePostTag(t *testing.T) {
 	joinPost.PostTags = updatePostTags
 	joinPost.Post.UpdateUserID = user3
 
-	updatedJoinPost, err := i.Update(&joinPost)
+	updatedJoinPost, err := i.Update(context.Background(), &joinPost)
 
 	afterPostTagCount := countPostTagByPostID(postID)
 
@@ -197,29 +308,54 @@ func TestUpdatePostTag(t *testing.T) {
 	assert.Equal(t, user3, updatedJoinPost.Post.UpdateUserID)
 }
 
-func selectUsers() (uint32, uint32, uint32) {
-	users := getUserData()
-	var num int = 1
+// TestRevertPostToRevision 更新毎に版が追加され、過去の版に差し戻せる事をテスト
+func TestRevertPostToRevision(t *testing.T) {
+	var i PostInteractor
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user1
+	joinPost := makeJoinPost(post, DemoUser, makePostTags(), nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+	postID := createdPost.Post.ID
 
-	var user1 uint32
-	var user2 uint32
-	var user3 uint32
-	for i := range users {
-		if num == 1 {
-			user1 = i
-			log.Println("user1", user1)
-		}
-		if num == 2 {
-			user2 = i
-			log.Println("user2", user2)
-		}
-		if num == 3 {
-			user3 = i
-			log.Println("user3", user3)
-		}
-		num++
-	}
-	return user1, user2, user3
+	// 他のユーザーが内容とタグを書き換える
+	updateJoinPost := makeJoinPost(model.Post{ID: postID, Title: "vandalized", Content: "vandalized", CreateUserID: user1, UpdateUserID: user2, Version: one}, DemoUser, []model.PostTag{{TagID: four}}, nil, nil)
+	_, err = i.Update(context.Background(), &updateJoinPost)
+	assert.Equal(t, nil, err)
+
+	revisions, err := i.ListRevisions(context.Background(), postID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(revisions))
+	assert.Equal(t, two, revisions[0].Revision.Revision)
+	assert.Equal(t, user2, revisions[0].Revision.UpdateUserID)
+
+	revision, diff, err := i.GetRevision(context.Background(), postID, two)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "vandalized", revision.Revision.Title)
+	assert.Equal(t, true, diff.TitleChanged)
+	assert.Equal(t, testTitle, diff.PreviousTitle)
+	assert.Equal(t, []uint32{four}, diff.AddedTagIDs)
+	assert.Equal(t, []uint32{one, two, three}, diff.RemovedTagIDs)
+
+	// 読み込み後に更新された場合は差し戻さない
+	_, err = i.RevertToRevision(context.Background(), postID, one, one, user3)
+	assert.Equal(t, true, errors.Is(err, ErrVersionConflict))
+
+	// 最初の版に差し戻す
+	_, err = i.RevertToRevision(context.Background(), postID, one, two, user3)
+	assert.Equal(t, nil, err)
+	readPost, err := i.GetByID(context.Background(), postID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, testTitle, readPost.Title)
+	assert.Equal(t, 3, countPostTagByPostID(postID))
+
+	revisions, err = i.ListRevisions(context.Background(), postID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(revisions))
+	assert.Equal(t, user3, revisions[0].Revision.UpdateUserID)
+
+	_, _, err = i.GetRevision(context.Background(), postID, four)
+	assert.Equal(t, true, errors.Is(err, ErrPostRevisionNotExists))
 }
 
 func TestLikePost(t *testing.T) {
@@ -228,12 +364,12 @@ func TestLikePost(t *testing.T) {
 	post := makePost(testTitle, testContent)
 	post.CreateUserID = user1
 	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 	assert.Equal(t, nil, err)
 	postID := createdPost.Post.ID
 	likeUser := &model.PostLikeUser{PostID: postID, UserID: user1}
 
-	_, err = i.Like(likeUser)
+	_, err = i.Like(context.Background(), likeUser)
 	assert.Equal(t, nil, err)
 
 	// likeしているユーザー数をカウントするテスト
@@ -241,7 +377,7 @@ func TestLikePost(t *testing.T) {
 	assert.Equal(t, 1, likeCount)
 
 	likeUser = &model.PostLikeUser{PostID: postID, UserID: user2}
-	_, err = i.Like(likeUser)
+	_, err = i.Like(context.Background(), likeUser)
 
 	// likeしているユーザー数が増えている事をテスト
 	likeCount = countPostLikeUserByPostID(postID)
@@ -253,7 +389,7 @@ func TestNotLikePost(t *testing.T) {
 	post := makePost(testTitle, testContent)
 	post.CreateUserID = user2
 	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 	assert.Equal(t, nil, err)
 	postID := createdPost.Post.ID
 	likeUsers := []model.PostLikeUser{
@@ -263,14 +399,14 @@ func TestNotLikePost(t *testing.T) {
 	}
 
 	for _, user := range likeUsers {
-		_, err = i.Like(&user)
+		_, err = i.Like(context.Background(), &user)
 	}
 	assert.Equal(t, nil, err)
 
 	beforeLikeCount := countPostLikeUserByPostID(postID)
 
 	// お気に入りを1件削除
-	_, err = i.NotLike(&model.PostLikeUser{PostID: postID, UserID: user1})
+	_, err = i.NotLike(context.Background(), &model.PostLikeUser{PostID: postID, UserID: user1})
 	afterLikeCount := countPostLikeUserByPostID(postID)
 
 	// likeしているユーザー数が1だけ減っている事をテスト
@@ -282,12 +418,12 @@ func TestCreateComment(t *testing.T) {
 	post := makePost(testTitle, testContent)
 	post.CreateUserID = user3
 	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 	assert.Equal(t, nil, err)
 
 	postID := createdPost.Post.ID
 	comment := makeComment(*createdPost.Post, testCommentContent)
-	_, err = i.CreateComment(&comment)
+	_, err = i.CreateComment(context.Background(), &comment)
 
 	assert.Equal(t, nil, err)
 
@@ -302,12 +438,12 @@ func TestCreateCommentNull(t *testing.T) {
 	post := makePost(testTitle, testContent)
 	post.CreateUserID = user1
 	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 	assert.Equal(t, nil, err)
 
 	postID := createdPost.Post.ID
 	comment := makeComment(*createdPost.Post, "")
-	_, err = i.CreateComment(&comment)
+	_, err = i.CreateComment(context.Background(), &comment)
 	count := countCommentByPostID(postID)
 
 	assert.NotEqual(t, nil, err)
@@ -319,12 +455,12 @@ func TestCreateCommentTooLong(t *testing.T) {
 	post := makePost(testTitle, testContent)
 	post.CreateUserID = user1
 	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 	assert.Equal(t, nil, err)
 
 	postID := createdPost.Post.ID
 	comment := makeComment(*createdPost.Post, "コメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入りますコメントが入ります")
-	_, err = i.CreateComment(&comment)
+	_, err = i.CreateComment(context.Background(), &comment)
 	count := countCommentByPostID(postID)
 
 	assert.NotEqual(t, nil, err)
@@ -336,11 +472,11 @@ func TestUpdateComment(t *testing.T) {
 	post := makePost(testTitle, testContent)
 	post.CreateUserID = user2
 	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 
 	assert.Equal(t, nil, err)
 	comment := makeComment(*createdPost.Post, testCommentContent)
-	createdComment, err := i.CreateComment(&comment)
+	createdComment, err := i.CreateComment(context.Background(), &comment)
 	updatedAt := createdComment.UpdatedAt
 
 	assert.Equal(t, nil, err)
@@ -349,35 +485,64 @@ func TestUpdateComment(t *testing.T) {
 	createdComment.CommentContent = "updated comment content"
 
 	time.Sleep(time.Second * 3)
-	updatedComment, err := i.UpdateComment(updateComment)
+	updatedComment, err := i.UpdateComment(context.Background(), updateComment)
 
-	readComment, err := getCommentByID(updatedComment.CommentID)
+	readComment, err := getCommentByID(context.Background(), updatedComment.CommentID)
 	assert.Equal(t, nil, err)
 	assert.NotEqual(t, testCommentContent, readComment.CommentContent)
 	assert.Equal(t, createdComment.CreatedAt, updatedComment.CreatedAt)
 	assert.NotEqual(t, updatedAt, updatedComment.UpdatedAt)
 }
 
+// TestUpdateCommentVersionConflict 古い版数でのコメント更新が失敗する事をテスト
+func TestUpdateCommentVersionConflict(t *testing.T) {
+	var i PostInteractor
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user2
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+
+	comment := makeComment(*createdPost.Post, testCommentContent)
+	createdComment, err := i.CreateComment(context.Background(), &comment)
+	assert.Equal(t, nil, err)
+	commentID := createdComment.CommentID
+
+	first := model.Comment{CommentID: commentID, PostID: comment.PostID, CreateUserID: user1, CommentContent: "first", Version: one}
+	second := model.Comment{CommentID: commentID, PostID: comment.PostID, CreateUserID: user1, CommentContent: "second", Version: one}
+
+	updatedComment, err := i.UpdateComment(context.Background(), &first)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, two, updatedComment.Version)
+
+	_, err = i.UpdateComment(context.Background(), &second)
+	assert.Equal(t, true, errors.Is(err, ErrVersionConflict))
+
+	readComment, err := getCommentByID(context.Background(), commentID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "first", readComment.CommentContent)
+}
+
 func TestDeleteComment(t *testing.T) {
 	var i PostInteractor
 	post := makePost(testTitle, testContent)
 	post.CreateUserID = user3
 	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
-	createdPost, err := i.Create(&joinPost)
+	createdPost, err := i.Create(context.Background(), &joinPost)
 	assert.Equal(t, nil, err)
 	postID := createdPost.Post.ID
 
 	comment := makeComment(*createdPost.Post, testCommentContent)
 
-	createdComment, err := i.CreateComment(&comment)
+	createdComment, err := i.CreateComment(context.Background(), &comment)
 	assert.Equal(t, nil, err)
 	beforeCommentCount := countCommentByPostID(postID)
 
-	assert.Equal(t, 0, beforeCommentCount)
+	assert.Equal(t, 1, beforeCommentCount)
 	commentID := createdComment.CommentID
 
 	// コメントを1件削除
-	err = i.DeleteComment(commentID)
+	err = i.DeleteComment(context.Background(), commentID)
 	assert.Equal(t, nil, err)
 
 	afterCommentCount := countCommentByPostID(postID)
@@ -387,14 +552,14 @@ func TestDeleteComment(t *testing.T) {
 
 // func TestGetAllPosts(t *testing.T) {
 // 	var i PostInteractor
-// 	posts, err := i.List("all", 0)
+// 	posts, err := i.List(context.Background(), "all", 0)
 // 	assert.Equal(t, nil, err)
 // 	assert.NotEqual(t, 0, len(posts))
 // }
 
 // func TestGetPostsByUserID(t *testing.T) {
 // 	var i PostInteractor
-// 	posts, err := i.List("create", user1)
+// 	posts, err := i.List(context.Background(), "create", user1)
 // 	assert.Equal(t, nil, err)
 // 	assert.NotEqual(t, 0, len(posts))
 
@@ -405,7 +570,7 @@ func TestDeleteComment(t *testing.T) {
 
 // func TestGetPostsByLikeUserID(t *testing.T) {
 // 	var i PostInteractor
-// 	posts, err := i.List("like", user2)
+// 	posts, err := i.List(context.Background(), "like", user2)
 // 	assert.Equal(t, nil, err)
 // 	assert.NotEqual(t, 0, len(posts))
 // }
@@ -413,15 +578,296 @@ func TestDeleteComment(t *testing.T) {
 // func TestGetPostsByTagID(t *testing.T) {
 // 	var i PostInteractor
 
-// 	posts, err := i.List("tag", one)
+// 	posts, err := i.List(context.Background(), "tag", one)
 // 	assert.Equal(t, nil, err)
 // 	assert.NotEqual(t, 0, len(posts))
 
-// 	posts, err = i.List("tag", four)
+// 	posts, err = i.List(context.Background(), "tag", four)
 // 	assert.Equal(t, nil, err)
 // 	assert.Equal(t, 1, len(posts))
 // }
 
+// TestListPostPagination ページトークンを辿って重複なく全件取得できる事をテスト
+func TestListPostPagination(t *testing.T) {
+	var i PostInteractor
+	for n := 0; n < 5; n++ {
+		post := makePost(testTitle, testContent)
+		post.CreateUserID = user1
+		joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+		_, err := i.Create(context.Background(), &joinPost)
+		assert.Equal(t, nil, err)
+	}
+
+	seen := map[uint32]bool{}
+	page := model.Pagination{PageSize: two}
+	for {
+		posts, nextPageToken, err := i.List(context.Background(), model.PostListCondition{Condition: "create", ID: user1, ViewerID: user1}, page)
+		assert.Equal(t, nil, err)
+		for _, post := range posts {
+			assert.Equal(t, false, seen[post.Post.ID])
+			seen[post.Post.ID] = true
+		}
+		if nextPageToken == "" {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	assert.Equal(t, countPostsByCreateUserID(user1), len(seen))
+
+	_, _, err := i.List(context.Background(), model.PostListCondition{Condition: "create", ID: user1}, model.Pagination{PageToken: "invalid"})
+	assert.Equal(t, ErrInvalidPageToken, err)
+}
+
+// TestListPostVisibility 下書きが投稿者本人にのみ返される事をテスト
+func TestListPostVisibility(t *testing.T) {
+	var i PostInteractor
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user2
+	post.Status = DraftPostStatus
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DraftPostStatus, createdPost.Post.Status)
+
+	draftIDs := func(viewerID uint32) []uint32 {
+		var ids []uint32
+		condition := model.PostListCondition{Condition: "create", ID: user2, ViewerID: viewerID, Status: DraftPostStatus}
+		posts, _, err := i.List(context.Background(), condition, model.Pagination{PageSize: maxPageSize})
+		assert.Equal(t, nil, err)
+		for _, post := range posts {
+			ids = append(ids, post.Post.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []uint32{createdPost.Post.ID}, draftIDs(user2))
+	assert.Equal(t, 0, len(draftIDs(user1)))
+	assert.Equal(t, 0, len(draftIDs(zero)))
+}
+
+// TestListPostFilter 複数の絞り込み条件を組み合わせて取得できる事をテスト
+func TestListPostFilter(t *testing.T) {
+	var i PostInteractor
+	const filterUserID uint32 = 8
+	withImage := makePost(testTitle, testContent)
+	withImage.CreateUserID = filterUserID
+	withImage.Image = "image.png"
+	imageJoinPost := makeJoinPost(withImage, DemoUser, []model.PostTag{{TagID: one}, {TagID: two}}, nil, nil)
+	imagePost, err := i.Create(context.Background(), &imageJoinPost)
+	assert.Equal(t, nil, err)
+
+	withoutImage := makePost(testTitle, testContent)
+	withoutImage.CreateUserID = filterUserID
+	textJoinPost := makeJoinPost(withoutImage, DemoUser, []model.PostTag{{TagID: one}}, nil, nil)
+	textPost, err := i.Create(context.Background(), &textJoinPost)
+	assert.Equal(t, nil, err)
+	comment := makeComment(*textPost.Post, testCommentContent)
+	_, err = i.CreateComment(context.Background(), &comment)
+	assert.Equal(t, nil, err)
+
+	listIDs := func(filter model.PostFilter) []uint32 {
+		var ids []uint32
+		condition := model.PostListCondition{Condition: "create", ID: filterUserID, Filter: filter}
+		posts, _, err := i.List(context.Background(), condition, model.Pagination{PageSize: maxPageSize})
+		assert.Equal(t, nil, err)
+		for _, post := range posts {
+			ids = append(ids, post.Post.ID)
+		}
+		return ids
+	}
+
+	zeroCount := uint32(0)
+	assert.Equal(t, []uint32{imagePost.Post.ID}, listIDs(model.PostFilter{TagIDs: []uint32{one, two}, TagMatch: model.TagMatchAll}))
+	assert.Equal(t, []uint32{textPost.Post.ID, imagePost.Post.ID}, listIDs(model.PostFilter{TagIDs: []uint32{one, two}}))
+	assert.Equal(t, []uint32{imagePost.Post.ID}, listIDs(model.PostFilter{Image: model.ImageWith}))
+	assert.Equal(t, []uint32{textPost.Post.ID}, listIDs(model.PostFilter{Image: model.ImageWithout, MinCommentCount: one}))
+	assert.Equal(t, []uint32{imagePost.Post.ID}, listIDs(model.PostFilter{TagIDs: []uint32{one}, MaxCommentCount: &zeroCount}))
+
+	_, _, err = i.List(context.Background(), model.PostListCondition{Condition: "unknown", ID: user3}, model.Pagination{})
+	assert.Equal(t, ErrInvalidListCondition, err)
+}
+
+// TestListPostSort 並び順ごとにページをまたいで正しい順序で取得できる事をテスト
+func TestListPostSort(t *testing.T) {
+	var i PostInteractor
+	const sortUserID uint32 = 9
+	var ids []uint32
+	for n := 0; n < 3; n++ {
+		post := makePost(testTitle, testContent)
+		post.CreateUserID = sortUserID
+		joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+		createdPost, err := i.Create(context.Background(), &joinPost)
+		assert.Equal(t, nil, err)
+		ids = append(ids, createdPost.Post.ID)
+	}
+	// 古い投稿ほどいいね、コメントを多くする
+	for n, id := range ids {
+		for u := n; u < len(ids); u++ {
+			_, err := i.Like(context.Background(), &model.PostLikeUser{PostID: id, UserID: uint32(u + 1)})
+			assert.Equal(t, nil, err)
+			comment := model.Comment{PostID: id, CreateUserID: uint32(u + 1), CommentContent: testCommentContent}
+			_, err = i.CreateComment(context.Background(), &comment)
+			assert.Equal(t, nil, err)
+		}
+	}
+
+	listIDs := func(sort model.PostSort) []uint32 {
+		var sorted []uint32
+		page := model.Pagination{PageSize: one}
+		for {
+			condition := model.PostListCondition{Condition: "create", ID: sortUserID, Sort: sort}
+			posts, nextPageToken, err := i.List(context.Background(), condition, page)
+			assert.Equal(t, nil, err)
+			for _, post := range posts {
+				sorted = append(sorted, post.Post.ID)
+			}
+			if nextPageToken == "" {
+				return sorted
+			}
+			page.PageToken = nextPageToken
+		}
+	}
+
+	reversed := []uint32{ids[2], ids[1], ids[0]}
+	assert.Equal(t, reversed, listIDs(model.PostSortNewest))
+	assert.Equal(t, ids, listIDs(model.PostSortOldest))
+	assert.Equal(t, ids, listIDs(model.PostSortMostLikes))
+	assert.Equal(t, ids, listIDs(model.PostSortMostComments))
+	assert.Equal(t, ids, listIDs(model.PostSortTrending))
+
+	// 別の並び順のトークンは使えない
+	_, nextPageToken, err := i.List(context.Background(), model.PostListCondition{Condition: "create", ID: sortUserID, Sort: model.PostSortMostLikes}, model.Pagination{PageSize: one})
+	assert.Equal(t, nil, err)
+	_, _, err = i.List(context.Background(), model.PostListCondition{Condition: "create", ID: sortUserID}, model.Pagination{PageSize: one, PageToken: nextPageToken})
+	assert.Equal(t, ErrInvalidPageToken, err)
+}
+
+// TestListPostCancelled キャンセルされたcontextではクエリが実行されない事をテスト
+func TestListPostCancelled(t *testing.T) {
+	var i PostInteractor
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := i.List(ctx, model.PostListCondition{}, model.Pagination{})
+	assert.Equal(t, context.Canceled, err)
+
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user1
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	_, err = i.Create(ctx, &joinPost)
+	assert.Equal(t, context.Canceled, err)
+}
+
+// TestUpdatePostStatus ステータス遷移の正常系、異常系
+func TestUpdatePostStatus(t *testing.T) {
+	var i PostInteractor
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user1
+	post.Status = DraftPostStatus
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+
+	createdPost.Post.Status = PublishedPostStatus
+	_, err = i.Update(context.Background(), createdPost)
+	assert.Equal(t, nil, err)
+
+	createdPost.Post.Status = HiddenPostStatus
+	_, err = i.Update(context.Background(), createdPost)
+	assert.Equal(t, nil, err)
+
+	// 非表示から下書きへは戻せない
+	createdPost.Post.Status = DraftPostStatus
+	_, err = i.Update(context.Background(), createdPost)
+	assert.Equal(t, ErrInvalidPostStatusTransition, err)
+
+	readPost, err := i.GetByID(context.Background(), createdPost.Post.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, HiddenPostStatus, readPost.Status)
+}
+
+// TestPublishScheduledPosts 公開予定日時を過ぎた予約投稿のみ公開される事をテスト
+func TestPublishScheduledPosts(t *testing.T) {
+	var i PostInteractor
+	publishAt := time.Now().Add(time.Hour)
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user1
+	post.PublishAt = &publishAt
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DraftPostStatus, createdPost.Post.Status)
+	postID := createdPost.Post.ID
+
+	// 公開予定日時前は投稿者以外から参照できない
+	_, err = i.GetJoinPostByID(context.Background(), postID, user2)
+	assert.NotEqual(t, nil, err)
+
+	_, err = i.PublishScheduledPosts(context.Background(), time.Now())
+	assert.Equal(t, nil, err)
+	readPost, err := i.GetByID(context.Background(), postID)
+	assert.Equal(t, DraftPostStatus, readPost.Status)
+
+	_, err = i.PublishScheduledPosts(context.Background(), publishAt.Add(time.Second))
+	assert.Equal(t, nil, err)
+	readPost, err = i.GetByID(context.Background(), postID)
+	assert.Equal(t, PublishedPostStatus, readPost.Status)
+}
+
+// TestSearchPosts 全文検索で参照可能な投稿のみ関連度順に返る事をテスト
+func TestSearchPosts(t *testing.T) {
+	i := PostInteractor{SearchIndex: search.NewMemoryIndex()}
+	var ids []uint32
+	for _, post := range []model.Post{
+		{Title: "東京のラーメン", Content: "醤油ラーメン", CreateUserID: user1},
+		{Title: "京都旅行", Content: "ラーメンも食べた", CreateUserID: user2},
+		{Title: "ラーメン下書き", Content: "ラーメン", CreateUserID: user2, Status: DraftPostStatus},
+		{Title: "削除するラーメン", Content: "ラーメン", CreateUserID: user1},
+	} {
+		joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+		createdPost, err := i.Create(context.Background(), &joinPost)
+		assert.Equal(t, nil, err)
+		ids = append(ids, createdPost.Post.ID)
+	}
+	err := i.DeleteByID(context.Background(), ids[3])
+	assert.Equal(t, nil, err)
+
+	searchIDs := func(query model.PostSearchQuery) []uint32 {
+		var result []uint32
+		posts, _, err := i.Search(context.Background(), query, model.Pagination{})
+		assert.Equal(t, nil, err)
+		for _, post := range posts {
+			result = append(result, post.Post.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []uint32{ids[0], ids[1]}, searchIDs(model.PostSearchQuery{Keyword: "ラーメン"}))
+	assert.Equal(t, 3, len(searchIDs(model.PostSearchQuery{Keyword: "ラーメン", ViewerID: user2})))
+	assert.Equal(t, []uint32{ids[1]}, searchIDs(model.PostSearchQuery{Keyword: "ラーメン", CreateUserIDs: []uint32{user2}}))
+
+	_, _, err = i.Search(context.Background(), model.PostSearchQuery{Keyword: " "}, model.Pagination{})
+	assert.Equal(t, ErrEmptySearchKeyword, err)
+}
+
+// TestRebuildSearchIndex 登録済みの投稿から作り直したインデックスで検索できる事をテスト
+func TestRebuildSearchIndex(t *testing.T) {
+	var i PostInteractor
+	post := model.Post{Title: "再構築するインデックス", Content: "つけ麺", CreateUserID: user1}
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+
+	i.SearchIndex = search.NewMemoryIndex()
+	err = i.RebuildSearchIndex(context.Background())
+	assert.Equal(t, nil, err)
+
+	posts, _, err := i.Search(context.Background(), model.PostSearchQuery{Keyword: "つけ麺"}, model.Pagination{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(posts))
+	assert.Equal(t, createdPost.Post.ID, posts[0].Post.ID)
+}
+
 func TestDeletePostsByUserID(t *testing.T) {
 	log.Println("user3", user3)
 	var i PostInteractor
@@ -440,15 +886,15 @@ func TestDeletePostsByUserID(t *testing.T) {
 		postTags := makePostTags()
 
 		joinPost := makeJoinPost(p, DemoUser, postTags, nil, nil)
-		jp, err := i.Create(&joinPost)
+		jp, err := i.Create(context.Background(), &joinPost)
 
 		assert.Equal(t, nil, err)
 		assert.Equal(t, user3, jp.Post.CreateUserID)
 	}
 
-	err := i.DeletePostsByUserID(user3)
+	err := i.DeletePostsByUserID(context.Background(), user3)
 	assert.Equal(t, nil, err)
-	posts, err = getPostsByCreateUserID(context.Background(), user3)
+	posts, _, err = getSortedPosts(filterPosts(db.GetDB(), model.PostFilter{CreateUserIDs: []uint32{user3}}), model.PostSortNewest, nil, maxPageSize)
 	assert.Equal(t, 0, len(posts))
 }
 
@@ -456,6 +902,12 @@ func TestDeleteCommentsByUserID(t *testing.T) {
 	log.Println("user2", user2)
 	var i PostInteractor
 	var comments []model.Comment
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user1
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	createdPost, err := i.Create(context.Background(), &joinPost)
+	assert.Equal(t, nil, err)
+	DemoComment.PostID = createdPost.Post.ID
 	DemoComment.CreateUserID = user2
 	DemoComment.CommentContent = testCommentContent
 	comments = append(comments, DemoComment)
@@ -466,18 +918,32 @@ func TestDeleteCommentsByUserID(t *testing.T) {
 	DemoComment.CreateUserID = user2
 	comments = append(comments, DemoComment)
 	for _, c := range comments {
-		comment, err := i.CreateComment(&c)
+		comment, err := i.CreateComment(context.Background(), &c)
 		assert.Equal(t, nil, err)
 		assert.Equal(t, user2, comment.CreateUserID)
 	}
 
-	err := i.DeleteCommentsByUserID(user2)
+	err = i.DeleteCommentsByUserID(context.Background(), user2)
 	assert.Equal(t, nil, err)
 
 	count := countCommentsByUserID(user2)
 	assert.Equal(t, 0, count)
 }
 
+func countPostsByCreateUserID(userID uint32) int {
+	var count int
+	DB := db.GetDB()
+	DB.Where("create_user_id = ?", userID).Model(&model.Post{}).Count(&count)
+	return count
+}
+
+func countCommentByPostIDUnscoped(postID uint32) int {
+	var count int
+	DB := db.GetDB()
+	DB.Unscoped().Where("post_id = ?", postID).Model(&model.Comment{}).Count(&count)
+	return count
+}
+
 func makePostTags() []model.PostTag {
 	return []model.PostTag{
 		{
@@ -512,11 +978,13 @@ func makeComment(post model.Post, comment string) model.Comment {
 
 func initTable() {
 	DB := db.GetDB()
-	// circleCIではリクエストを実行できていないためコメントアウト
-	// user1, user2, user3 = selectUsers()
 	user1, user2, user3 = one, two, three
 	DB.Delete(&model.Post{})
 	DB.Delete(&model.Tag{})
 	DB.Delete(&model.PostTag{})
 	DB.Delete(&model.PostLikeUser{})
+	// 投稿タグは登録済みのタグのみ紐付けられるため、テストで使うタグを登録しておく
+	for id := uint32(1); id <= testTagCount; id++ {
+		DB.Create(&model.Tag{ID: id, TagName: testTagName, CreateUserID: testUserID, Status: ValidTagStatus})
+	}
 }
@@ -0,0 +1,64 @@
+package interactor
+
+import (
+	"context"
+	"log"
+
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// PostRelations 投稿IDごとに分類した、投稿に紐付くタグ、いいね、コメント
+type PostRelations struct {
+	PostTags      map[uint32][]model.PostTag
+	PostLikeUsers map[uint32][]model.PostLikeUser
+	Comments      map[uint32][]model.Comment
+}
+
+// listPostRelations 複数の投稿に紐付くタグ、いいね、コメントを取得する
+// 投稿の件数によらず、紐付けの種類ごとに1回ずつIN句で取得する
+func listPostRelations(ctx context.Context, postIDs []uint32) (PostRelations, error) {
+	relations := PostRelations{
+		PostTags:      map[uint32][]model.PostTag{},
+		PostLikeUsers: map[uint32][]model.PostLikeUser{},
+		Comments:      map[uint32][]model.Comment{},
+	}
+	if len(postIDs) == 0 {
+		return relations, nil
+	}
+	DB := db.Conn(ctx)
+
+	// 有効なタグのみ紐付ける
+	var postTagRows []model.PostTag
+	err := DB.Select("post_tags.*").
+		Joins("inner join tags on tags.id = post_tags.tag_id and tags.status = 1").
+		Where("post_tags.post_id IN (?)", postIDs).
+		Find(&postTagRows).Error
+	if err != nil {
+		log.Println("Error occured")
+		return relations, err
+	}
+	for _, postTag := range postTagRows {
+		relations.PostTags[postTag.PostID] = append(relations.PostTags[postTag.PostID], postTag)
+	}
+
+	var likeUserRows []model.PostLikeUser
+	if err := DB.Where("post_id IN (?)", postIDs).Find(&likeUserRows).Error; err != nil {
+		log.Println("Error occured")
+		return relations, err
+	}
+	for _, likeUser := range likeUserRows {
+		relations.PostLikeUsers[likeUser.PostID] = append(relations.PostLikeUsers[likeUser.PostID], likeUser)
+	}
+
+	var commentRows []model.Comment
+	if err := DB.Order("created_at").Where("post_id IN (?)", postIDs).Find(&commentRows).Error; err != nil {
+		log.Println("Error occured")
+		return relations, err
+	}
+	for _, comment := range commentRows {
+		relations.Comments[comment.PostID] = append(relations.Comments[comment.PostID], comment)
+	}
+
+	return relations, nil
+}
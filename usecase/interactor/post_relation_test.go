@@ -0,0 +1,96 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// listPostRelationsPerPost 投稿1件ごとにタグ、いいね、コメントを取得する、一括取得前の方法
+// ベンチマークで一括取得とクエリ数を比較するために使う
+func listPostRelationsPerPost(ctx context.Context, postIDs []uint32) (PostRelations, error) {
+	relations := PostRelations{
+		PostTags:      map[uint32][]model.PostTag{},
+		PostLikeUsers: map[uint32][]model.PostLikeUser{},
+		Comments:      map[uint32][]model.Comment{},
+	}
+	DB := db.Conn(ctx)
+	for _, postID := range postIDs {
+		var postTagRows []model.PostTag
+		err := DB.Select("post_tags.*").
+			Joins("inner join tags on tags.id = post_tags.tag_id and tags.status = 1").
+			Where("post_tags.post_id = ?", postID).
+			Find(&postTagRows).Error
+		if err != nil {
+			return relations, err
+		}
+		relations.PostTags[postID] = postTagRows
+
+		var likeUserRows []model.PostLikeUser
+		if err := DB.Where("post_id = ?", postID).Find(&likeUserRows).Error; err != nil {
+			return relations, err
+		}
+		relations.PostLikeUsers[postID] = likeUserRows
+
+		var commentRows []model.Comment
+		if err := DB.Order("created_at").Where("post_id = ?", postID).Find(&commentRows).Error; err != nil {
+			return relations, err
+		}
+		relations.Comments[postID] = commentRows
+	}
+	return relations, nil
+}
+
+// BenchmarkListPostRelations ページサイズごとに、紐付け情報の取得で発行されるクエリ数を、投稿1件ごとの取得と一括取得で比較する
+// 一括取得の queries/op がページサイズによらず一定である事を確認する
+func BenchmarkListPostRelations(b *testing.B) {
+	DB := db.GetDB()
+	var queries int64
+	countQuery := func(scope *gorm.Scope) { atomic.AddInt64(&queries, 1) }
+	db.Callback().Query().After("gorm:query").Register("benchmark:count_query", countQuery)
+	defer db.Callback().Query().Remove("benchmark:count_query")
+
+	var postIDs []uint32
+	for n := uint32(0); n < maxPageSize; n++ {
+		post := makePost(testTitle, testContent)
+		if err := DB.Create(&post).Error; err != nil {
+			b.Fatal(err)
+		}
+		postIDs = append(postIDs, post.ID)
+		DB.Create(&model.PostTag{PostID: post.ID, TagID: one})
+		DB.Create(&model.PostLikeUser{PostID: post.ID, UserID: one})
+		DB.Create(&model.Comment{PostID: post.ID, CreateUserID: one, CommentContent: testContent})
+	}
+	defer func() {
+		DB.Where("post_id IN (?)", postIDs).Delete(&model.PostTag{})
+		DB.Where("post_id IN (?)", postIDs).Delete(&model.PostLikeUser{})
+		DB.Unscoped().Where("post_id IN (?)", postIDs).Delete(&model.Comment{})
+		DB.Unscoped().Where("id IN (?)", postIDs).Delete(&model.Post{})
+	}()
+
+	paths := []struct {
+		name string
+		list func(ctx context.Context, postIDs []uint32) (PostRelations, error)
+	}{
+		{"per_post", listPostRelationsPerPost},
+		{"batched", listPostRelations},
+	}
+	for _, path := range paths {
+		for _, size := range []int{1, 10, 50, 100} {
+			b.Run(fmt.Sprintf("%s/page_size=%d", path.name, size), func(b *testing.B) {
+				atomic.StoreInt64(&queries, 0)
+				for i := 0; i < b.N; i++ {
+					if _, err := path.list(context.Background(), postIDs[:size]); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(atomic.LoadInt64(&queries))/float64(b.N), "queries/op")
+			})
+		}
+	}
+}
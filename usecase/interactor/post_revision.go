@@ -0,0 +1,208 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// ErrPostRevisionNotExists 指定した更新履歴が存在しない時のエラー
+var ErrPostRevisionNotExists = errors.New("post revision not exists")
+
+// ListRevisions 投稿の更新履歴を新しい順に取得する
+func (p *PostInteractor) ListRevisions(ctx context.Context, postID uint32) ([]model.JoinPostRevision, error) {
+	var revisions []model.PostRevision
+	DB := db.Conn(ctx)
+
+	if err := DB.Where("post_id = ?", postID).Order("revision desc").Find(&revisions).Error; err != nil {
+		log.Println("Error occured")
+		return nil, err
+	}
+	return joinRevisionTags(DB, revisions)
+}
+
+// GetRevision 投稿の更新履歴1件と、直前の版との差分を取得する
+func (p *PostInteractor) GetRevision(ctx context.Context, postID uint32, revision uint32) (model.JoinPostRevision, model.PostRevisionDiff, error) {
+	DB := db.Conn(ctx)
+
+	current, err := getRevision(DB, postID, revision)
+	if err != nil {
+		return model.JoinPostRevision{}, model.PostRevisionDiff{}, err
+	}
+	var previous *model.JoinPostRevision
+	if revision > 1 {
+		prev, err := getRevision(DB, postID, revision-1)
+		if err != nil {
+			return model.JoinPostRevision{}, model.PostRevisionDiff{}, err
+		}
+		previous = &prev
+	}
+	return current, DiffRevisions(previous, current), nil
+}
+
+// RevertToRevision 投稿の件名、内容、タグを指定した版の内容に戻す
+// 差し戻しも1件の更新として新しい版が追加される。version は読み込み時の投稿の版数
+func (p *PostInteractor) RevertToRevision(ctx context.Context, postID uint32, revision uint32, version uint32, userID uint32) (*model.JoinPost, error) {
+	target, err := getRevision(db.Conn(ctx), postID, revision)
+	if err != nil {
+		return nil, err
+	}
+	current, err := p.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	var postTags []model.PostTag
+	for _, tagID := range target.TagIDs {
+		postTags = append(postTags, model.PostTag{PostID: postID, TagID: tagID})
+	}
+	joinPost := &model.JoinPost{
+		Post: &model.Post{
+			ID:           postID,
+			Title:        target.Revision.Title,
+			Content:      target.Revision.Content,
+			CreateUserID: current.CreateUserID,
+			UpdateUserID: userID,
+			// 読み込み後に他の更新があった場合は差し戻さない
+			Version: version,
+		},
+		PostTags: postTags,
+	}
+	return p.Update(ctx, joinPost)
+}
+
+// recordRevision 投稿の現在の内容を新しい版として登録する
+func recordRevision(tx *gorm.DB, postID uint32, updateUserID uint32) error {
+	var post model.Post
+	var postTags []model.PostTag
+	var latest model.PostRevision
+
+	if err := tx.First(&post, postID).Error; err != nil {
+		return wrapNotFound(err, ResourcePost, postID)
+	}
+	if err := tx.Where("post_id = ?", postID).Find(&postTags).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("post_id = ?", postID).Order("revision desc").Limit(1).Find(&latest).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+
+	revision := &model.PostRevision{
+		PostID:       postID,
+		Revision:     latest.Revision + 1,
+		Title:        post.Title,
+		Content:      post.Content,
+		UpdateUserID: updateUserID,
+	}
+	if err := tx.Create(revision).Error; err != nil {
+		return err
+	}
+	for _, postTag := range postTags {
+		revisionTag := &model.PostRevisionTag{RevisionID: revision.ID, TagID: postTag.TagID}
+		if err := tx.Create(revisionTag).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordInitialRevision 更新履歴のない投稿に、更新前の内容を最初の版として登録する
+// 更新履歴の導入前に作成された投稿の内容を残すために用いる
+func recordInitialRevision(tx *gorm.DB, postID uint32) error {
+	var count int
+	if err := tx.Model(&model.PostRevision{}).Where("post_id = ?", postID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	var post model.Post
+	if err := tx.First(&post, postID).Error; err != nil {
+		return wrapNotFound(err, ResourcePost, postID)
+	}
+	return recordRevision(tx, postID, post.CreateUserID)
+}
+
+// getRevision 投稿IDと版数を元に更新履歴を1件取得する
+func getRevision(DB *gorm.DB, postID uint32, revision uint32) (model.JoinPostRevision, error) {
+	var row model.PostRevision
+	if err := DB.Where("post_id = ? AND revision = ?", postID, revision).First(&row).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return model.JoinPostRevision{}, NewRevisionNotFoundError(postID)
+		}
+		return model.JoinPostRevision{}, err
+	}
+	revisions, err := joinRevisionTags(DB, []model.PostRevision{row})
+	if err != nil {
+		return model.JoinPostRevision{}, err
+	}
+	return revisions[0], nil
+}
+
+// joinRevisionTags 更新履歴に版ごとのタグIDを紐付けて返す
+func joinRevisionTags(DB *gorm.DB, revisions []model.PostRevision) ([]model.JoinPostRevision, error) {
+	var ids []uint32
+	var revisionTags []model.PostRevisionTag
+	joinRevisions := []model.JoinPostRevision{}
+
+	if len(revisions) == 0 {
+		return joinRevisions, nil
+	}
+	for _, revision := range revisions {
+		ids = append(ids, revision.ID)
+	}
+	if err := DB.Where("revision_id IN (?)", ids).Order("tag_id").Find(&revisionTags).Error; err != nil {
+		return nil, err
+	}
+	tagIDs := map[uint32][]uint32{}
+	for _, revisionTag := range revisionTags {
+		tagIDs[revisionTag.RevisionID] = append(tagIDs[revisionTag.RevisionID], revisionTag.TagID)
+	}
+	for _, revision := range revisions {
+		joinRevisions = append(joinRevisions, model.JoinPostRevision{
+			Revision: revision,
+			TagIDs:   tagIDs[revision.ID],
+		})
+	}
+	return joinRevisions, nil
+}
+
+// DiffRevisions 直前の版からの変更内容を返す。previousがnilの場合は最初の版として扱う
+func DiffRevisions(previous *model.JoinPostRevision, current model.JoinPostRevision) model.PostRevisionDiff {
+	if previous == nil {
+		return model.PostRevisionDiff{
+			TitleChanged:   true,
+			ContentChanged: true,
+			AddedTagIDs:    current.TagIDs,
+		}
+	}
+	diff := model.PostRevisionDiff{
+		PreviousRevision: previous.Revision.Revision,
+		TitleChanged:     previous.Revision.Title != current.Revision.Title,
+		PreviousTitle:    previous.Revision.Title,
+		ContentChanged:   previous.Revision.Content != current.Revision.Content,
+		PreviousContent:  previous.Revision.Content,
+	}
+	diff.AddedTagIDs = subtractTagIDs(current.TagIDs, previous.TagIDs)
+	diff.RemovedTagIDs = subtractTagIDs(previous.TagIDs, current.TagIDs)
+	return diff
+}
+
+// subtractTagIDs aに含まれ、bに含まれないタグIDを返す
+func subtractTagIDs(a []uint32, b []uint32) []uint32 {
+	var result []uint32
+	exists := map[uint32]bool{}
+	for _, id := range b {
+		exists[id] = true
+	}
+	for _, id := range a {
+		if !exists[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
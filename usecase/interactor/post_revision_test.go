@@ -0,0 +1,43 @@
+package interactor
+
+import (
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// TestDiffRevisions 直前の版との差分をテスト
+func TestDiffRevisions(t *testing.T) {
+	previous := model.JoinPostRevision{
+		Revision: model.PostRevision{Revision: one, Title: "title", Content: "content"},
+		TagIDs:   []uint32{one, two},
+	}
+	current := model.JoinPostRevision{
+		Revision: model.PostRevision{Revision: two, Title: "title", Content: "content updated"},
+		TagIDs:   []uint32{two, three},
+	}
+
+	diff := DiffRevisions(&previous, current)
+	assert.Equal(t, one, diff.PreviousRevision)
+	assert.Equal(t, false, diff.TitleChanged)
+	assert.Equal(t, true, diff.ContentChanged)
+	assert.Equal(t, "content", diff.PreviousContent)
+	assert.Equal(t, []uint32{three}, diff.AddedTagIDs)
+	assert.Equal(t, []uint32{one}, diff.RemovedTagIDs)
+}
+
+// TestDiffFirstRevision 最初の版は全て追加として扱う事をテスト
+func TestDiffFirstRevision(t *testing.T) {
+	current := model.JoinPostRevision{
+		Revision: model.PostRevision{Revision: one, Title: "title", Content: "content"},
+		TagIDs:   []uint32{one},
+	}
+
+	diff := DiffRevisions(nil, current)
+	assert.Equal(t, zero, diff.PreviousRevision)
+	assert.Equal(t, true, diff.TitleChanged)
+	assert.Equal(t, true, diff.ContentChanged)
+	assert.Equal(t, []uint32{one}, diff.AddedTagIDs)
+	assert.Equal(t, 0, len(diff.RemovedTagIDs))
+}
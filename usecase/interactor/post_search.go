@@ -0,0 +1,151 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// MaxSearchHits 全文検索インデックスから取得する最大件数
+const MaxSearchHits = 1000
+
+var (
+	// ErrEmptySearchKeyword 検索キーワードが空の時のエラー
+	ErrEmptySearchKeyword = errors.New("search keyword is empty")
+	// ErrSearchIndexNotConfigured 全文検索インデックスが設定されていない時のエラー
+	ErrSearchIndexNotConfigured = errors.New("search index is not configured")
+)
+
+// Search キーワードに一致する投稿を関連度順に1ページ分取得し、次ページのトークンと共に返す
+// 閲覧ユーザーが参照できない投稿は結果から除く
+func (p *PostInteractor) Search(ctx context.Context, query model.PostSearchQuery, page model.Pagination) ([]model.JoinPost, string, error) {
+	if strings.TrimSpace(query.Keyword) == "" {
+		return []model.JoinPost{}, "", ErrEmptySearchKeyword
+	}
+	if p.SearchIndex == nil {
+		return []model.JoinPost{}, "", ErrSearchIndexNotConfigured
+	}
+	offset, err := DecodeSearchCursor(page.PageToken)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	size := NormalizePageSize(page.PageSize)
+
+	hits, err := p.SearchIndex.Search(ctx, query, MaxSearchHits)
+	if err != nil {
+		log.Println("Error occured while searching posts")
+		return []model.JoinPost{}, "", err
+	}
+	posts, err := getVisiblePostsByHits(ctx, hits, query.ViewerID)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+
+	posts, nextPageToken := SplitSearchPage(posts, offset, size)
+	if len(posts) == 0 {
+		return []model.JoinPost{}, "", nil
+	}
+
+	joinPosts, err := p.createJoinPosts(ctx, posts)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	return joinPosts, nextPageToken, nil
+}
+
+// SplitSearchPage 関連度順の結果から1ページ分を切り出し、次ページのトークンと共に返す
+func SplitSearchPage(posts []model.Post, offset uint32, size uint32) ([]model.Post, string) {
+	if offset >= uint32(len(posts)) {
+		return []model.Post{}, ""
+	}
+	posts = posts[offset:]
+	if uint32(len(posts)) <= size {
+		return posts, ""
+	}
+	return posts[:size], encodeSearchCursor(offset + size)
+}
+
+// getVisiblePostsByHits 検索結果の投稿のうち、閲覧ユーザーが参照できるものを関連度順に返す
+func getVisiblePostsByHits(ctx context.Context, hits []model.SearchHit, viewerID uint32) ([]model.Post, error) {
+	var ids []uint32
+	var rows []model.Post
+	if len(hits) == 0 {
+		return []model.Post{}, nil
+	}
+	for _, hit := range hits {
+		ids = append(ids, hit.PostID)
+	}
+
+	query := visiblePosts(db.Conn(ctx), viewerID, 0)
+	if err := query.Where("posts.id IN (?)", ids).Find(&rows).Error; err != nil {
+		log.Println("Error occured")
+		return nil, err
+	}
+	postsByID := map[uint32]model.Post{}
+	for _, row := range rows {
+		postsByID[row.ID] = row
+	}
+	posts := []model.Post{}
+	for _, hit := range hits {
+		if post, ok := postsByID[hit.PostID]; ok {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
+// indexPost 投稿を全文検索インデックスに登録する
+// インデックスの更新に失敗しても投稿の更新は取り消さない
+func (p *PostInteractor) indexPost(ctx context.Context, postID uint32) {
+	var post model.Post
+	var postTags []model.PostTag
+	var tagIDs []uint32
+	if p.SearchIndex == nil {
+		return
+	}
+
+	DB := db.Conn(ctx)
+	if err := DB.First(&post, postID).Error; err != nil {
+		log.Printf("Error happend while indexing post ID: %v\n", postID)
+		return
+	}
+	DB.Where("post_id = ?", postID).Find(&postTags)
+	for _, postTag := range postTags {
+		tagIDs = append(tagIDs, postTag.TagID)
+	}
+	if err := p.SearchIndex.Index(ctx, post, tagIDs); err != nil {
+		log.Printf("Error happend while indexing post ID: %v, %v\n", postID, err)
+	}
+}
+
+// unindexPosts 投稿を全文検索インデックスから削除する
+func (p *PostInteractor) unindexPosts(ctx context.Context, ids ...uint32) {
+	if p.SearchIndex == nil {
+		return
+	}
+	for _, id := range ids {
+		if err := p.SearchIndex.Remove(ctx, id); err != nil {
+			log.Printf("Error happend while removing post ID: %v from index, %v\n", id, err)
+		}
+	}
+}
+
+// RebuildSearchIndex 論理削除されていない全投稿を全文検索インデックスに登録し直す
+// プロセス内に保持するインデックスを起動時に復元するために使う
+func (p *PostInteractor) RebuildSearchIndex(ctx context.Context) error {
+	var ids []uint32
+	if p.SearchIndex == nil {
+		return nil
+	}
+	if err := db.Conn(ctx).Model(&model.Post{}).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		p.indexPost(ctx, id)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,140 @@
+package interactor
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+const (
+	// likeCountQuery 投稿のいいね数を求めるサブクエリ
+	likeCountQuery = "(SELECT COUNT(*) FROM post_like_users WHERE post_like_users.post_id = posts.id)"
+	// commentCountQuery 投稿のコメント数(削除済みを除く)を求めるサブクエリ
+	commentCountQuery = "(SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL)"
+)
+
+// trendingScoreQuery トレンドスコアを求める式
+// (いいね数 + コメント数) を (経過時間 + 2)^1.5 で減衰させ、比較できるよう整数に丸める
+// 経過秒数の求め方のみDBごとに異なる
+func trendingScoreQuery() string {
+	return "CAST(FLOOR((" + likeCountQuery + " + " + commentCountQuery + ") * 1000000 / POW(GREATEST(" + db.ElapsedSecondsSQL("posts.created_at") + ", 0) / 3600 + 2, 1.5)) AS SIGNED)"
+}
+
+// ErrInvalidPostSort 存在しない並び順が指定された時のエラー
+var ErrInvalidPostSort = errors.New("invalid post sort")
+
+// SortedPost 並び替えの値を付与した投稿
+type SortedPost struct {
+	model.Post
+	SortKey int64
+}
+
+// sortKeyQuery 並び順ごとの並び替えの値を求める式と引数を返す
+// 作成日時順の場合は空文字を返す
+func sortKeyQuery(sort model.PostSort, now time.Time) (string, []interface{}) {
+	switch sort {
+	case model.PostSortMostLikes:
+		return likeCountQuery, nil
+	case model.PostSortMostComments:
+		return commentCountQuery, nil
+	case model.PostSortTrending:
+		return trendingScoreQuery(), []interface{}{now}
+	}
+	return "", nil
+}
+
+// IsKeyedSort 並び替えの値(いいね数、コメント数、トレンドスコア)で並べる並び順か判定する
+func IsKeyedSort(sort model.PostSort) bool {
+	switch sort {
+	case model.PostSortMostLikes, model.PostSortMostComments, model.PostSortTrending:
+		return true
+	}
+	return false
+}
+
+// ValidatePostSort 並び順とページトークンの組み合わせを検証する
+// 別の並び順で発行されたトークンは位置を表せないため不正とする
+func ValidatePostSort(sort model.PostSort, cursor *PostCursor) error {
+	if sort > model.PostSortTrending {
+		return ErrInvalidPostSort
+	}
+	if cursor != nil && cursor.Sort != sort {
+		return ErrInvalidPageToken
+	}
+	return nil
+}
+
+// SortReferenceTime トレンドスコアの基準日時を返す
+// 2ページ目以降は先頭ページ取得時の日時を用いる
+func SortReferenceTime(cursor *PostCursor) time.Time {
+	if cursor != nil && cursor.Now != 0 {
+		return time.Unix(cursor.Now, 0)
+	}
+	return time.Now().Truncate(time.Second)
+}
+
+// TrendingScore trendingScoreQuery と同じ式でトレンドスコアを求める
+func TrendingScore(reactions int64, createdAt time.Time, now time.Time) int64 {
+	elapsed := math.Max(float64(int64(now.Sub(createdAt)/time.Second)), 0)
+	return int64(math.Floor(float64(reactions) * 1000000 / math.Pow(elapsed/3600+2, 1.5)))
+}
+
+// getSortedPosts 指定の並び順で投稿を1ページ分取得し、次ページのトークンと共に返す
+// トレンドスコアはページをまたいで順序が変わらないよう、先頭ページ取得時の日時を基準に計算する
+func getSortedPosts(query *gorm.DB, sort model.PostSort, cursor *PostCursor, size uint32) ([]model.Post, string, error) {
+	now := SortReferenceTime(cursor)
+
+	keyQuery, keyArgs := sortKeyQuery(sort, now)
+	if keyQuery == "" {
+		var rows []model.Post
+		if err := paginatePostsBy(query, sort, cursor, size).Find(&rows).Error; err != nil {
+			return nil, "", err
+		}
+		if uint32(len(rows)) <= size {
+			return rows, "", nil
+		}
+		rows = rows[:size]
+		return rows, EncodeSortedPostCursor(rows[len(rows)-1], sort, 0, time.Time{}), nil
+	}
+
+	var rows []SortedPost
+	query = query.Table("posts").Select("posts.*, "+keyQuery+" AS sort_key", keyArgs...)
+	if cursor != nil {
+		createdAt := time.Unix(0, cursor.CreatedAt)
+		args := append(append([]interface{}{}, keyArgs...), cursor.Key)
+		args = append(append(args, keyArgs...), cursor.Key, createdAt, createdAt, cursor.ID)
+		query = query.Where(keyQuery+" < ? OR ("+keyQuery+" = ? AND (posts.created_at < ? OR (posts.created_at = ? AND posts.id < ?)))", args...)
+	}
+	err := query.Order("sort_key desc").Order("posts.created_at desc").Order("posts.id desc").Limit(size + 1).Find(&rows).Error
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextPageToken string
+	if uint32(len(rows)) > size {
+		rows = rows[:size]
+		last := rows[len(rows)-1]
+		nextPageToken = EncodeSortedPostCursor(last.Post, sort, last.SortKey, now)
+	}
+	posts := make([]model.Post, 0, len(rows))
+	for _, row := range rows {
+		posts = append(posts, row.Post)
+	}
+	return posts, nextPageToken, nil
+}
+
+// paginatePostsBy 作成日時順でカーソル位置より後ろの投稿をsize+1件取得するクエリを返す
+func paginatePostsBy(query *gorm.DB, sort model.PostSort, cursor *PostCursor, size uint32) *gorm.DB {
+	if sort != model.PostSortOldest {
+		return paginatePosts(query, cursor, size)
+	}
+	if cursor != nil {
+		createdAt := time.Unix(0, cursor.CreatedAt)
+		query = query.Where("posts.created_at > ? OR (posts.created_at = ? AND posts.id > ?)", createdAt, createdAt, cursor.ID)
+	}
+	return query.Order("posts.created_at asc").Order("posts.id asc").Limit(size + 1)
+}
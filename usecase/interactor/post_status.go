@@ -0,0 +1,134 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+const (
+	// DraftPostStatus 下書きステータス
+	DraftPostStatus uint32 = 1
+	// PublishedPostStatus 公開ステータス
+	PublishedPostStatus uint32 = 2
+	// ArchivedPostStatus アーカイブステータス
+	ArchivedPostStatus uint32 = 3
+	// HiddenPostStatus 非表示ステータス
+	HiddenPostStatus uint32 = 4
+)
+
+var (
+	// ErrInvalidPostStatus 存在しない投稿ステータスが指定された時のエラー
+	ErrInvalidPostStatus = errors.New("invalid post status")
+	// ErrInvalidPostStatusTransition 許可されていないステータス遷移のエラー
+	ErrInvalidPostStatusTransition = errors.New("invalid post status transition")
+	// ErrInvalidPublishAt 公開予定日時が日時として不正な時のエラー
+	ErrInvalidPublishAt = errors.New("invalid publish_at")
+)
+
+// postStatusTransitions 遷移元ステータスごとの遷移可能なステータス
+// モデレーターは全てのステータスから非表示にでき、非表示からの遷移はモデレーターのみ行える
+var postStatusTransitions = map[uint32][]uint32{
+	DraftPostStatus:     {PublishedPostStatus, ArchivedPostStatus},
+	PublishedPostStatus: {DraftPostStatus, ArchivedPostStatus, HiddenPostStatus},
+	ArchivedPostStatus:  {PublishedPostStatus},
+	HiddenPostStatus:    {PublishedPostStatus, ArchivedPostStatus},
+}
+
+// InitialPostStatus 新規作成時のステータスを決定する
+// 未指定の場合は公開、作成時に指定できるのは下書きか公開のみ
+func InitialPostStatus(status uint32) (uint32, error) {
+	switch status {
+	case 0:
+		return PublishedPostStatus, nil
+	case DraftPostStatus, PublishedPostStatus:
+		return status, nil
+	}
+	return 0, ErrInvalidPostStatus
+}
+
+// moderatorContextKey モデレーターによる操作である事を示すコンテキストのキー
+type moderatorContextKey struct{}
+
+// WithModerator モデレーターによる操作である事をコンテキストに設定する
+func WithModerator(ctx context.Context) context.Context {
+	return context.WithValue(ctx, moderatorContextKey{}, true)
+}
+
+// isModerator モデレーターによる操作か判定する
+func isModerator(ctx context.Context) bool {
+	moderator, _ := ctx.Value(moderatorContextKey{}).(bool)
+	return moderator
+}
+
+// CheckPostStatusChange 投稿のステータスを to に変更できるか判定する
+// モデレーター以外が非表示の投稿のステータスを変更する場合は権限なしとする
+func CheckPostStatusChange(ctx context.Context, current model.Post, to uint32) error {
+	moderator := isModerator(ctx)
+	if err := checkPostStatusTransition(current.Status, to, moderator); err != nil {
+		return err
+	}
+	if current.Status == HiddenPostStatus && to != HiddenPostStatus && !moderator {
+		return NewPermissionDeniedError(ResourcePost, current.ID)
+	}
+	return nil
+}
+
+// checkPostStatusTransition from から to へのステータス遷移が可能か判定する
+// moderator がtrueの場合は、全てのステータスから非表示への遷移を許可する
+func checkPostStatusTransition(from uint32, to uint32, moderator bool) error {
+	if _, ok := postStatusTransitions[to]; !ok {
+		return ErrInvalidPostStatus
+	}
+	if from == to {
+		return nil
+	}
+	if moderator && to == HiddenPostStatus {
+		return nil
+	}
+	for _, next := range postStatusTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return ErrInvalidPostStatusTransition
+}
+
+// IsScheduled 公開予定日時が未来に設定されているか判定する
+func IsScheduled(post *model.Post, now time.Time) bool {
+	return post.PublishAt != nil && post.PublishAt.After(now)
+}
+
+// ClearsPublishAt 下書きに戻す更新で、公開予定日時を取り消すか判定する
+// 過ぎた公開予定日時が残ったままだと、予約投稿として再び公開されるため、新しい日時の指定がない場合は取り消す
+func ClearsPublishAt(from uint32, post *model.Post) bool {
+	return from != DraftPostStatus && post.Status == DraftPostStatus && post.PublishAt == nil
+}
+
+// IsVisiblePost 閲覧ユーザーが投稿を参照できるか判定する
+// 公開中かつ公開予定日時を過ぎた投稿は全員、それ以外は投稿者本人のみ参照できる
+func IsVisiblePost(post *model.Post, viewerID uint32, now time.Time) bool {
+	if viewerID != 0 && post.CreateUserID == viewerID {
+		return true
+	}
+	return post.Status == PublishedPostStatus && !IsScheduled(post, now)
+}
+
+// visiblePosts 閲覧ユーザーが参照できる投稿に絞り込むクエリを返す
+// IsVisiblePost と同じ条件をSQLで表す
+func visiblePosts(query *gorm.DB, viewerID uint32, status uint32) *gorm.DB {
+	published := "posts.status = ? AND (posts.publish_at IS NULL OR posts.publish_at <= ?)"
+	now := time.Now()
+	if viewerID == 0 {
+		query = query.Where(published, PublishedPostStatus, now)
+	} else {
+		query = query.Where("("+published+") OR posts.create_user_id = ?", PublishedPostStatus, now, viewerID)
+	}
+	if status != 0 {
+		query = query.Where("posts.status = ?", status)
+	}
+	return query
+}
@@ -0,0 +1,91 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// TestInitialPostStatus 作成時に指定できるステータスをテスト
+func TestInitialPostStatus(t *testing.T) {
+	status, err := InitialPostStatus(0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, PublishedPostStatus, status)
+
+	status, err = InitialPostStatus(DraftPostStatus)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DraftPostStatus, status)
+
+	for _, status := range []uint32{ArchivedPostStatus, HiddenPostStatus, 9} {
+		_, err = InitialPostStatus(status)
+		assert.Equal(t, ErrInvalidPostStatus, err)
+	}
+}
+
+// TestCheckPostStatusTransition ステータス遷移の可否をテスト
+func TestCheckPostStatusTransition(t *testing.T) {
+	tests := []struct {
+		from      uint32
+		to        uint32
+		moderator bool
+		want      error
+	}{
+		{DraftPostStatus, DraftPostStatus, false, nil},
+		{DraftPostStatus, PublishedPostStatus, false, nil},
+		{DraftPostStatus, ArchivedPostStatus, false, nil},
+		{DraftPostStatus, HiddenPostStatus, false, ErrInvalidPostStatusTransition},
+		{DraftPostStatus, HiddenPostStatus, true, nil},
+		{PublishedPostStatus, DraftPostStatus, false, nil},
+		{PublishedPostStatus, ArchivedPostStatus, false, nil},
+		{PublishedPostStatus, HiddenPostStatus, false, nil},
+		{ArchivedPostStatus, PublishedPostStatus, false, nil},
+		{ArchivedPostStatus, DraftPostStatus, false, ErrInvalidPostStatusTransition},
+		{ArchivedPostStatus, HiddenPostStatus, false, ErrInvalidPostStatusTransition},
+		{ArchivedPostStatus, HiddenPostStatus, true, nil},
+		{HiddenPostStatus, PublishedPostStatus, true, nil},
+		{HiddenPostStatus, DraftPostStatus, true, ErrInvalidPostStatusTransition},
+		{PublishedPostStatus, 9, true, ErrInvalidPostStatus},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, checkPostStatusTransition(tt.from, tt.to, tt.moderator))
+	}
+}
+
+// TestCheckPostStatusChange 非表示の投稿のステータスをモデレーターのみ変更できる事をテスト
+func TestCheckPostStatusChange(t *testing.T) {
+	hidden := model.Post{ID: one, Status: HiddenPostStatus}
+	ctx := context.Background()
+
+	err := CheckPostStatusChange(ctx, hidden, PublishedPostStatus)
+	assert.Equal(t, KindPermissionDenied, KindOf(err))
+	assert.Equal(t, nil, CheckPostStatusChange(ctx, hidden, HiddenPostStatus))
+	assert.Equal(t, nil, CheckPostStatusChange(WithModerator(ctx), hidden, PublishedPostStatus))
+	assert.Equal(t, nil, CheckPostStatusChange(WithModerator(ctx), model.Post{ID: one, Status: DraftPostStatus}, HiddenPostStatus))
+}
+
+// TestIsVisiblePost 公開前の投稿が投稿者本人にのみ見える事をテスト
+func TestIsVisiblePost(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		post     model.Post
+		viewerID uint32
+		want     bool
+	}{
+		{model.Post{CreateUserID: one, Status: PublishedPostStatus}, zero, true},
+		{model.Post{CreateUserID: one, Status: PublishedPostStatus, PublishAt: &past}, two, true},
+		{model.Post{CreateUserID: one, Status: PublishedPostStatus, PublishAt: &future}, two, false},
+		{model.Post{CreateUserID: one, Status: DraftPostStatus, PublishAt: &future}, two, false},
+		{model.Post{CreateUserID: one, Status: DraftPostStatus, PublishAt: &future}, one, true},
+		{model.Post{CreateUserID: one, Status: HiddenPostStatus}, zero, false},
+		{model.Post{CreateUserID: one, Status: ArchivedPostStatus}, one, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsVisiblePost(&tt.post, tt.viewerID, now))
+	}
+}
@@ -0,0 +1,126 @@
+package interactor
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// Restore ゴミ箱に移動した投稿を元に戻す
+func (p *PostInteractor) Restore(ctx context.Context, id uint32) error {
+	DB := db.Conn(ctx)
+	result := DB.Unscoped().Model(&model.Post{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", gorm.Expr("NULL"))
+	if err := result.Error; err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return NewNotFoundError(ResourcePost, id)
+	}
+	p.indexPost(ctx, id)
+	return nil
+}
+
+// GetTrashedByID ゴミ箱に移動した投稿を1件取得する
+func (p *PostInteractor) GetTrashedByID(ctx context.Context, id uint32) (model.Post, error) {
+	var post model.Post
+	DB := db.Conn(ctx)
+	if err := DB.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&post).Error; err != nil {
+		return model.Post{}, wrapNotFound(err, ResourcePost, id)
+	}
+	return post, nil
+}
+
+// ListTrashed ゴミ箱に移動したユーザーの投稿を1ページ分取得し、次ページのトークンと共に返す
+func (p *PostInteractor) ListTrashed(ctx context.Context, userID uint32, page model.Pagination) ([]model.JoinPost, string, error) {
+	var posts []model.Post
+	cursor, err := DecodePostCursor(page.PageToken)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	size := NormalizePageSize(page.PageSize)
+
+	DB := db.Conn(ctx)
+	query := DB.Unscoped().Where("posts.deleted_at IS NOT NULL AND posts.create_user_id = ?", userID)
+	if err := paginatePosts(query, cursor, size).Find(&posts).Error; err != nil {
+		log.Println("Error occured")
+		return []model.JoinPost{}, "", err
+	}
+	posts, nextPageToken := SplitPage(posts, size)
+
+	joinPosts, err := p.createJoinPosts(ctx, posts)
+	if err != nil {
+		return []model.JoinPost{}, "", err
+	}
+	return joinPosts, nextPageToken, nil
+}
+
+// Purge ゴミ箱に移動した投稿を、紐付け情報と共に完全に削除する
+func (p *PostInteractor) Purge(ctx context.Context, id uint32) error {
+	var post model.Post
+	DB := db.Conn(ctx)
+
+	if err := DB.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&post).Error; err != nil {
+		return wrapNotFound(err, ResourcePost, id)
+	}
+
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		return purgePosts(db.Conn(ctx), []uint32{id})
+	})
+	if err != nil {
+		return err
+	}
+	p.unindexPosts(ctx, id)
+	return nil
+}
+
+// PurgeTrashed before より前にゴミ箱に移動した投稿、コメントを完全に削除し、削除した投稿件数を返す
+func (p *PostInteractor) PurgeTrashed(ctx context.Context, before time.Time) (int64, error) {
+	var ids []uint32
+	DB := db.Conn(ctx)
+
+	if err := DB.Unscoped().Model(&model.Post{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+
+	err := db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+		if len(ids) > 0 {
+			if err := purgePosts(tx, ids); err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("deleted_at < ?", before).Delete(&model.Comment{}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	p.unindexPosts(ctx, ids...)
+	return int64(len(ids)), nil
+}
+
+// purgePosts 投稿と、紐付けられたタグ、お気に入り、コメント、更新履歴を物理削除する
+func purgePosts(tx *gorm.DB, ids []uint32) error {
+	if err := tx.Where("post_id IN (?)", ids).Delete(&model.PostTag{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("post_id IN (?)", ids).Delete(&model.PostLikeUser{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("post_id IN (?)", ids).Delete(&model.Comment{}).Error; err != nil {
+		return err
+	}
+	revisionIDs := tx.Model(&model.PostRevision{}).Select("id").Where("post_id IN (?)", ids).QueryExpr()
+	if err := tx.Where("revision_id IN (?)", revisionIDs).Delete(&model.PostRevisionTag{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("post_id IN (?)", ids).Delete(&model.PostRevision{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN (?)", ids).Delete(&model.Post{}).Error
+}
@@ -0,0 +1,45 @@
+package interactor
+
+import (
+	"context"
+	"log"
+
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// UnknownUserName ユーザー情報を取得できなかったユーザーの表示名
+const UnknownUserName = "unknown user"
+
+// LookupUsers 投稿、いいね、コメントに関わるユーザー情報をまとめて取得する
+// UserServiceに接続できない場合、存在しないユーザーの場合は表示名をUnknownUserNameとし、投稿の取得は続ける
+func LookupUsers(ctx context.Context, directory repository.UserDirectory, posts []model.Post, relations PostRelations) map[uint32]model.User {
+	var ids []uint32
+	for _, post := range posts {
+		ids = append(ids, post.CreateUserID)
+		for _, likeUser := range relations.PostLikeUsers[post.ID] {
+			ids = append(ids, likeUser.UserID)
+		}
+		for _, comment := range relations.Comments[post.ID] {
+			ids = append(ids, comment.CreateUserID)
+		}
+	}
+	ids = UniqueIDs(ids)
+
+	users := map[uint32]model.User{}
+	if directory != nil {
+		found, err := directory.LookupUsers(ctx, ids)
+		if err != nil {
+			log.Printf("Error happend while looking up users: %v\n", err)
+		}
+		for id, user := range found {
+			users[id] = user
+		}
+	}
+	for _, id := range ids {
+		if _, ok := users[id]; !ok {
+			users[id] = model.User{ID: id, UserName: UnknownUserName}
+		}
+	}
+	return users
+}
@@ -0,0 +1,34 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/userdirectory"
+)
+
+// TestLookupUsers 投稿者、いいね、コメントのユーザー情報が取得され、取得できないユーザーはUnknownUserNameになる事をテスト
+func TestLookupUsers(t *testing.T) {
+	directory := userdirectory.NewMemoryDirectory(model.User{ID: 1, UserName: "testuser1"})
+	posts := []model.Post{{ID: 10, CreateUserID: 1}}
+	relations := PostRelations{
+		PostLikeUsers: map[uint32][]model.PostLikeUser{10: {{PostID: 10, UserID: 2}}},
+		Comments:      map[uint32][]model.Comment{10: {{PostID: 10, CreateUserID: 1}}},
+	}
+
+	users := LookupUsers(context.Background(), directory, posts, relations)
+	assert.Equal(t, "testuser1", users[1].UserName)
+	assert.Equal(t, model.User{ID: 2, UserName: UnknownUserName}, users[2])
+
+	// UserServiceの障害時も全てのユーザーをUnknownUserNameとして返す
+	directory.SetError(errors.New("unavailable"))
+	users = LookupUsers(context.Background(), directory, posts, relations)
+	assert.Equal(t, UnknownUserName, users[1].UserName)
+
+	// 参照先が未設定の場合
+	users = LookupUsers(context.Background(), nil, posts, relations)
+	assert.Equal(t, 2, len(users))
+}
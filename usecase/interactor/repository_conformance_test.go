@@ -0,0 +1,648 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/db"
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/search"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// repositoryFactory 空の状態のPostRepository, TagRepositoryを返す
+type repositoryFactory func(t *testing.T) (repository.PostRepository, repository.TagRepository)
+
+// TestGORMRepositoryConformance PostInteractor, TagInteractorの共通仕様をテスト
+func TestGORMRepositoryConformance(t *testing.T) {
+	// 全テーブルを空にするため、後続のテストが使うタグを登録し直す
+	defer initTable()
+	testRepositoryConformance(t, func(t *testing.T) (repository.PostRepository, repository.TagRepository) {
+		DB := db.GetDB()
+		for _, value := range []interface{}{
+			&model.Post{}, &model.Tag{}, &model.PostTag{}, &model.PostLikeUser{},
+			&model.Comment{}, &model.PostRevision{}, &model.PostRevisionTag{},
+		} {
+			if err := DB.Unscoped().Delete(value).Error; err != nil {
+				t.Fatal(err)
+			}
+		}
+		return &uncheckedPostInteractor{&PostInteractor{SearchIndex: search.NewMemoryIndex()}}, &TagInteractor{}
+	})
+}
+
+// testRepositoryConformance 全ての実装が満たすべき仕様をテスト
+// サブテストごとに空の状態のリポジトリを用いる
+func testRepositoryConformance(t *testing.T, newRepositories repositoryFactory) {
+	cases := []struct {
+		name string
+		test func(t *testing.T, posts repository.PostRepository, tags repository.TagRepository)
+	}{
+		{"CreatePost", testConformanceCreatePost},
+		{"CreatePostInvalid", testConformanceCreatePostInvalid},
+		{"ScheduledPost", testConformanceScheduledPost},
+		{"UpdatePost", testConformanceUpdatePost},
+		{"UpdatePostStatus", testConformanceUpdatePostStatus},
+		{"Revisions", testConformanceRevisions},
+		{"Trash", testConformanceTrash},
+		{"PurgeTrashed", testConformancePurgeTrashed},
+		{"ListPagination", testConformanceListPagination},
+		{"ListFilterAndSort", testConformanceListFilterAndSort},
+		{"LikeAndComment", testConformanceLikeAndComment},
+		{"DeleteByUserID", testConformanceDeleteByUserID},
+		{"Search", testConformanceSearch},
+		{"Tags", testConformanceTags},
+		{"Integrity", testConformanceIntegrity},
+		{"IntegrityOrphans", testConformanceIntegrityOrphans},
+		{"Cancelled", testConformanceCancelled},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			posts, tags := newRepositories(t)
+			c.test(t, posts, tags)
+		})
+	}
+}
+
+func testConformanceCreatePost(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	valid := createConformanceTag(t, tags, "valid", ValidTagStatus)
+	invalid := createConformanceTag(t, tags, "invalid", InValidTagStatus)
+
+	created := createConformancePost(t, posts, one, valid.ID, invalid.ID)
+	assert.NotEqual(t, zero, created.ID)
+	assert.Equal(t, one, created.Version)
+	assert.Equal(t, PublishedPostStatus, created.Status)
+
+	// 無効なタグは紐付けずに返す
+	joinPost, err := posts.GetJoinPostByID(ctx, created.ID, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(joinPost.PostTags))
+	assert.Equal(t, valid.ID, joinPost.PostTags[0].TagID)
+	assert.Equal(t, UnknownUserName, joinPost.User.UserName)
+
+	_, err = posts.GetByID(ctx, created.ID+1000)
+	assert.Equal(t, KindNotFound, KindOf(err))
+}
+
+func testConformanceCreatePostInvalid(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	post := makePost(testTitle, "")
+	post.CreateUserID = one
+	_, err := posts.Create(ctx, &model.JoinPost{Post: &post})
+	assert.NotEqual(t, nil, err)
+
+	post = makePost(testTitle, testContent)
+	post.CreateUserID = one
+	post.Status = ArchivedPostStatus
+	_, err = posts.Create(ctx, &model.JoinPost{Post: &post})
+	assert.Equal(t, ErrInvalidPostStatus, err)
+
+	rows, _, err := posts.List(ctx, model.PostListCondition{ViewerID: one}, model.Pagination{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(rows))
+}
+
+func testConformanceScheduledPost(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	publishAt := time.Now().Add(time.Hour)
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = one
+	post.PublishAt = &publishAt
+	_, err := posts.Create(ctx, &model.JoinPost{Post: &post})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DraftPostStatus, post.Status)
+
+	// 公開されるまでは投稿者本人のみ参照できる
+	_, err = posts.GetJoinPostByID(ctx, post.ID, two)
+	assert.Equal(t, KindNotFound, KindOf(err))
+	_, err = posts.GetJoinPostByID(ctx, post.ID, one)
+	assert.Equal(t, nil, err)
+
+	count, err := posts.PublishScheduledPosts(ctx, time.Now())
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(0), count)
+	count, err = posts.PublishScheduledPosts(ctx, publishAt.Add(time.Second))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1), count)
+
+	published, err := posts.GetByID(ctx, post.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, PublishedPostStatus, published.Status)
+
+	// 公開後に下書きに戻した投稿は、過ぎた公開予定日時で再び公開されない
+	update := &model.JoinPost{Post: &model.Post{ID: post.ID, Title: testTitle, Content: testContent, CreateUserID: one, Status: DraftPostStatus, Version: published.Version}}
+	_, err = posts.Update(ctx, update)
+	assert.Equal(t, nil, err)
+	count, err = posts.PublishScheduledPosts(ctx, publishAt.Add(time.Minute))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(0), count)
+	unpublished, err := posts.GetByID(ctx, post.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, DraftPostStatus, unpublished.Status)
+	assert.Equal(t, true, unpublished.PublishAt == nil)
+}
+
+func testConformanceUpdatePost(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	tag1 := createConformanceTag(t, tags, "tag1", ValidTagStatus)
+	tag2 := createConformanceTag(t, tags, "tag2", ValidTagStatus)
+	created := makePost(testTitle, testContent)
+	created.CreateUserID = one
+	created.Image = "image"
+	_, err := posts.Create(ctx, &model.JoinPost{Post: &created, PostTags: []model.PostTag{{TagID: tag1.ID}}})
+	assert.Equal(t, nil, err)
+
+	update := &model.JoinPost{
+		Post:     &model.Post{ID: created.ID, Title: testTitle, Content: "Content updated", CreateUserID: three, UpdateUserID: two, Version: one},
+		PostTags: []model.PostTag{{TagID: tag2.ID}},
+	}
+	_, err = posts.Update(ctx, update)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, two, update.Post.Version)
+
+	// 値を指定しなかった項目は変更されない
+	joinPost, err := posts.GetJoinPostByID(ctx, created.ID, one)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "image", joinPost.Post.Image)
+	assert.Equal(t, "Content updated", joinPost.Post.Content)
+	assert.Equal(t, two, joinPost.Post.UpdateUserID)
+	// 作成ユーザーは変更されない
+	assert.Equal(t, one, joinPost.Post.CreateUserID)
+	assert.Equal(t, 1, len(joinPost.PostTags))
+	assert.Equal(t, tag2.ID, joinPost.PostTags[0].TagID)
+
+	// 古い版数での更新は失敗する
+	update.Post.Version = one
+	_, err = posts.Update(ctx, update)
+	assert.Equal(t, true, errors.Is(err, ErrVersionConflict))
+
+	// 版数を指定しない更新は失敗する
+	update.Post.Version = 0
+	_, err = posts.Update(ctx, update)
+	assert.Equal(t, true, errors.Is(err, ErrVersionRequired))
+	assert.Equal(t, KindInvalidArgument, KindOf(err))
+	joinPost, err = posts.GetJoinPostByID(ctx, created.ID, one)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, two, joinPost.Post.Version)
+
+	// 存在しない投稿は更新できない
+	missing := &model.JoinPost{Post: &model.Post{ID: created.ID + 1000, Title: testTitle, Content: testContent, CreateUserID: one, Version: one}}
+	_, err = posts.Update(ctx, missing)
+	assert.Equal(t, KindNotFound, KindOf(err))
+}
+
+func testConformanceUpdatePostStatus(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	created := createConformancePost(t, posts, one)
+
+	update := &model.JoinPost{Post: &model.Post{ID: created.ID, Title: testTitle, Content: testContent, CreateUserID: one, Status: DraftPostStatus, Version: one}}
+	_, err := posts.Update(ctx, update)
+	assert.Equal(t, nil, err)
+
+	// 下書きは投稿者本人のみ参照できる
+	_, err = posts.GetJoinPostByID(ctx, created.ID, two)
+	assert.Equal(t, KindNotFound, KindOf(err))
+
+	update.Post.Status = HiddenPostStatus
+	_, err = posts.Update(ctx, update)
+	assert.Equal(t, ErrInvalidPostStatusTransition, err)
+
+	update.Post.Status = 99
+	_, err = posts.Update(ctx, update)
+	assert.Equal(t, ErrInvalidPostStatus, err)
+
+	// モデレーターは下書きからも非表示にできる
+	update.Post.Status = HiddenPostStatus
+	_, err = posts.Update(WithModerator(ctx), update)
+	assert.Equal(t, nil, err)
+
+	// 非表示の解除はモデレーターのみ行える
+	update.Post.Status = PublishedPostStatus
+	_, err = posts.Update(ctx, update)
+	assert.Equal(t, KindPermissionDenied, KindOf(err))
+	hidden, err := posts.GetByID(ctx, created.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, HiddenPostStatus, hidden.Status)
+
+	_, err = posts.Update(WithModerator(ctx), update)
+	assert.Equal(t, nil, err)
+}
+
+func testConformanceRevisions(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	tag := createConformanceTag(t, tags, "tag", ValidTagStatus)
+	created := createConformancePost(t, posts, one, tag.ID)
+
+	update := &model.JoinPost{Post: &model.Post{ID: created.ID, Title: "Title updated", Content: testContent, CreateUserID: one, UpdateUserID: one, Version: one}}
+	_, err := posts.Update(ctx, update)
+	assert.Equal(t, nil, err)
+
+	revisions, err := posts.ListRevisions(ctx, created.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(revisions))
+	assert.Equal(t, two, revisions[0].Revision.Revision)
+	assert.Equal(t, "Title updated", revisions[0].Revision.Title)
+
+	revision, diff, err := posts.GetRevision(ctx, created.ID, two)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Title updated", revision.Revision.Title)
+	assert.Equal(t, true, diff.TitleChanged)
+	assert.Equal(t, false, diff.ContentChanged)
+	assert.Equal(t, []uint32{tag.ID}, diff.RemovedTagIDs)
+
+	_, _, err = posts.GetRevision(ctx, created.ID, 9)
+	assert.Equal(t, true, errors.Is(err, ErrPostRevisionNotExists))
+
+	// 差し戻しは新しい版として登録される
+	_, err = posts.RevertToRevision(ctx, created.ID, one, two, two)
+	assert.Equal(t, nil, err)
+	reverted, err := posts.GetJoinPostByID(ctx, created.ID, one)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, testTitle, reverted.Post.Title)
+	assert.Equal(t, 1, len(reverted.PostTags))
+	revisions, err = posts.ListRevisions(ctx, created.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(revisions))
+	assert.Equal(t, two, revisions[0].Revision.UpdateUserID)
+}
+
+func testConformanceTrash(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	tag := createConformanceTag(t, tags, "tag", ValidTagStatus)
+	created := createConformancePost(t, posts, one, tag.ID)
+
+	// ゴミ箱にない投稿は復元、完全削除できない
+	assert.Equal(t, KindNotFound, KindOf(posts.Restore(ctx, created.ID)))
+	assert.NotEqual(t, nil, posts.Purge(ctx, created.ID))
+	_, err := posts.GetTrashedByID(ctx, created.ID)
+	assert.Equal(t, KindNotFound, KindOf(err))
+
+	assert.Equal(t, nil, posts.DeleteByID(ctx, created.ID))
+	_, err = posts.GetByID(ctx, created.ID)
+	assert.Equal(t, KindNotFound, KindOf(err))
+	found, err := posts.GetTrashedByID(ctx, created.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, one, found.CreateUserID)
+	trashed, _, err := posts.ListTrashed(ctx, one, model.Pagination{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(trashed))
+
+	// 復元時はタグの紐付けも元に戻る
+	assert.Equal(t, nil, posts.Restore(ctx, created.ID))
+	restored, err := posts.GetJoinPostByID(ctx, created.ID, one)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(restored.PostTags))
+
+	assert.Equal(t, nil, posts.DeleteByID(ctx, created.ID))
+	assert.Equal(t, nil, posts.Purge(ctx, created.ID))
+	assert.NotEqual(t, nil, posts.Restore(ctx, created.ID))
+	revisions, err := posts.ListRevisions(ctx, created.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(revisions))
+}
+
+func testConformancePurgeTrashed(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	created := createConformancePost(t, posts, one)
+	assert.Equal(t, nil, posts.DeleteByID(ctx, created.ID))
+
+	count, err := posts.PurgeTrashed(ctx, time.Now().AddDate(0, 0, -1))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(0), count)
+
+	count, err = posts.PurgeTrashed(ctx, time.Now().Add(time.Second))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1), count)
+	assert.NotEqual(t, nil, posts.Restore(ctx, created.ID))
+}
+
+func testConformanceListPagination(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	var ids []uint32
+	for n := 0; n < 5; n++ {
+		ids = append(ids, createConformancePost(t, posts, one).ID)
+	}
+
+	// 作成日時の新しい順に、重複なく全件取得できる
+	var listed []uint32
+	var pages int
+	page := model.Pagination{PageSize: 2}
+	for {
+		rows, next, err := posts.List(ctx, model.PostListCondition{}, page)
+		assert.Equal(t, nil, err)
+		for _, row := range rows {
+			listed = append(listed, row.Post.ID)
+		}
+		pages++
+		if next == "" {
+			break
+		}
+		page.PageToken = next
+	}
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []uint32{ids[4], ids[3], ids[2], ids[1], ids[0]}, listed)
+
+	_, _, err := posts.List(ctx, model.PostListCondition{}, model.Pagination{PageToken: "invalid"})
+	assert.Equal(t, ErrInvalidPageToken, err)
+	_, _, err = posts.List(ctx, model.PostListCondition{Condition: "unknown"}, model.Pagination{})
+	assert.Equal(t, ErrInvalidListCondition, err)
+}
+
+func testConformanceListFilterAndSort(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	tag := createConformanceTag(t, tags, "tag", ValidTagStatus)
+	first := createConformancePost(t, posts, one, tag.ID)
+	second := createConformancePost(t, posts, two)
+	third := createConformancePost(t, posts, three, tag.ID)
+
+	for _, userID := range []uint32{one, two} {
+		_, err := posts.Like(ctx, &model.PostLikeUser{PostID: second.ID, UserID: userID})
+		assert.Equal(t, nil, err)
+	}
+	_, err := posts.Like(ctx, &model.PostLikeUser{PostID: first.ID, UserID: three})
+	assert.Equal(t, nil, err)
+	comment := makeComment(third, testCommentContent)
+	comment.CreateUserID = one
+	_, err = posts.CreateComment(ctx, &comment)
+	assert.Equal(t, nil, err)
+
+	cases := []struct {
+		name      string
+		condition model.PostListCondition
+		want      []uint32
+	}{
+		{"newest", model.PostListCondition{}, []uint32{third.ID, second.ID, first.ID}},
+		{"oldest", model.PostListCondition{Sort: model.PostSortOldest}, []uint32{first.ID, second.ID, third.ID}},
+		{"most likes", model.PostListCondition{Sort: model.PostSortMostLikes}, []uint32{second.ID, first.ID, third.ID}},
+		{"most comments", model.PostListCondition{Sort: model.PostSortMostComments}, []uint32{third.ID, second.ID, first.ID}},
+		{"tag", model.PostListCondition{Condition: "tag", ID: tag.ID}, []uint32{third.ID, first.ID}},
+		{"like", model.PostListCondition{Condition: "like", ID: three}, []uint32{first.ID}},
+		{"create", model.PostListCondition{Filter: model.PostFilter{CreateUserIDs: []uint32{one, two}}}, []uint32{second.ID, first.ID}},
+		{"comment count", model.PostListCondition{Filter: model.PostFilter{MinCommentCount: 1}}, []uint32{third.ID}},
+	}
+	for _, c := range cases {
+		rows, _, err := posts.List(ctx, c.condition, model.Pagination{})
+		assert.Equal(t, nil, err)
+		var got []uint32
+		for _, row := range rows {
+			got = append(got, row.Post.ID)
+		}
+		assert.Equal(t, c.want, got)
+	}
+
+	// 別の並び順で発行されたトークンは使えない
+	_, next, err := posts.List(ctx, model.PostListCondition{Sort: model.PostSortMostLikes}, model.Pagination{PageSize: 1})
+	assert.Equal(t, nil, err)
+	_, _, err = posts.List(ctx, model.PostListCondition{}, model.Pagination{PageToken: next})
+	assert.Equal(t, ErrInvalidPageToken, err)
+}
+
+func testConformanceLikeAndComment(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	created := createConformancePost(t, posts, one)
+
+	like := &model.PostLikeUser{PostID: created.ID, UserID: two}
+	_, err := posts.Like(ctx, like)
+	assert.Equal(t, nil, err)
+	joinPost, err := posts.GetJoinPostByID(ctx, created.ID, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(joinPost.PostLikeUsers))
+	_, err = posts.NotLike(ctx, like)
+	assert.Equal(t, nil, err)
+
+	comment := makeComment(created, testCommentContent)
+	comment.CreateUserID = two
+	_, err = posts.CreateComment(ctx, &comment)
+	assert.Equal(t, nil, err)
+	assert.NotEqual(t, zero, comment.CommentID)
+	assert.Equal(t, one, comment.Version)
+	found, err := posts.GetCommentByID(ctx, comment.CommentID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, two, found.CreateUserID)
+
+	update := model.Comment{CommentID: comment.CommentID, PostID: created.ID, CreateUserID: comment.CreateUserID, CommentContent: "updated", Version: one}
+	_, err = posts.UpdateComment(ctx, &update)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, two, update.Version)
+	assert.Equal(t, "updated", update.CommentContent)
+
+	update.Version = one
+	_, err = posts.UpdateComment(ctx, &update)
+	assert.Equal(t, true, errors.Is(err, ErrVersionConflict))
+
+	// 版数を指定しない更新は失敗する
+	update.Version = 0
+	_, err = posts.UpdateComment(ctx, &update)
+	assert.Equal(t, KindInvalidArgument, KindOf(err))
+
+	joinPost, err = posts.GetJoinPostByID(ctx, created.ID, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(joinPost.PostLikeUsers))
+	assert.Equal(t, 1, len(joinPost.Comments))
+
+	assert.Equal(t, nil, posts.DeleteComment(ctx, comment.CommentID))
+	joinPost, err = posts.GetJoinPostByID(ctx, created.ID, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(joinPost.Comments))
+	_, err = posts.GetCommentByID(ctx, comment.CommentID)
+	assert.Equal(t, KindNotFound, KindOf(err))
+	update.Version = two
+	_, err = posts.UpdateComment(ctx, &update)
+	assert.Equal(t, KindNotFound, KindOf(err))
+
+	invalid := makeComment(created, "")
+	invalid.CreateUserID = two
+	_, err = posts.CreateComment(ctx, &invalid)
+	assert.NotEqual(t, nil, err)
+}
+
+func testConformanceDeleteByUserID(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	deleted := createConformancePost(t, posts, one)
+	kept := createConformancePost(t, posts, two)
+	comment := makeComment(kept, testCommentContent)
+	comment.CreateUserID = one
+	_, err := posts.CreateComment(ctx, &comment)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, nil, posts.DeletePostsByUserID(ctx, one))
+	assert.Equal(t, nil, posts.DeleteCommentsByUserID(ctx, one))
+
+	_, err = posts.GetByID(ctx, deleted.ID)
+	assert.Equal(t, KindNotFound, KindOf(err))
+	joinPost, err := posts.GetJoinPostByID(ctx, kept.ID, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(joinPost.Comments))
+}
+
+func testConformanceSearch(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	matched := makePost("検索対象", testContent)
+	matched.CreateUserID = one
+	_, err := posts.Create(ctx, &model.JoinPost{Post: &matched})
+	assert.Equal(t, nil, err)
+	createConformancePost(t, posts, one)
+
+	rows, _, err := posts.Search(ctx, model.PostSearchQuery{Keyword: "検索"}, model.Pagination{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(rows))
+	assert.Equal(t, matched.ID, rows[0].Post.ID)
+
+	// ゴミ箱に移動した投稿は検索されない
+	assert.Equal(t, nil, posts.DeleteByID(ctx, matched.ID))
+	rows, _, err = posts.Search(ctx, model.PostSearchQuery{Keyword: "検索"}, model.Pagination{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(rows))
+
+	_, _, err = posts.Search(ctx, model.PostSearchQuery{Keyword: " "}, model.Pagination{})
+	assert.Equal(t, ErrEmptySearchKeyword, err)
+}
+
+func testConformanceTags(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	valid := createConformanceTag(t, tags, "valid", ValidTagStatus)
+	invalid := createConformanceTag(t, tags, "invalid", InValidTagStatus)
+
+	_, err := tags.Create(ctx, &model.Tag{TagName: "", CreateUserID: testUserID})
+	assert.NotEqual(t, nil, err)
+
+	found, err := tags.GetTagByTagName(ctx, "valid")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, valid.ID, found.ID)
+	found, err = tags.GetTagByTagID(ctx, invalid.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "invalid", found.TagName)
+
+	validTags, err := tags.ListValidTag(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(validTags))
+	assert.Equal(t, valid.ID, validTags[0].ID)
+
+	// 指定したタグのみ更新される
+	_, err = tags.Update(ctx, &model.Tag{ID: invalid.ID, TagName: "invalid", CreateUserID: testUserID, Status: ValidTagStatus})
+	assert.Equal(t, nil, err)
+	found, err = tags.GetTagByTagID(ctx, valid.ID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "valid", found.TagName)
+	validTags, err = tags.ListValidTag(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(validTags))
+
+	assert.Equal(t, nil, tags.DeleteByID(ctx, valid.ID))
+	_, err = tags.GetTagByTagID(ctx, valid.ID)
+	assert.Equal(t, KindNotFound, KindOf(err))
+	all, err := tags.List(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(all))
+}
+
+func testConformanceCancelled(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	createConformancePost(t, posts, one)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := posts.List(ctx, model.PostListCondition{}, model.Pagination{})
+	assert.NotEqual(t, nil, err)
+	_, err = tags.List(ctx)
+	assert.NotEqual(t, nil, err)
+}
+
+func testConformanceIntegrity(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	ctx := context.Background()
+	tag := createConformanceTag(t, tags, "tag", ValidTagStatus)
+	created := createConformancePost(t, posts, one, tag.ID)
+
+	// 同じユーザーは1回のみお気に入りできる
+	like := &model.PostLikeUser{PostID: created.ID, UserID: two}
+	_, err := posts.Like(ctx, like)
+	assert.Equal(t, nil, err)
+	_, err = posts.Like(ctx, like)
+	assert.Equal(t, true, errors.Is(err, ErrAlreadyLiked))
+
+	// 存在しない投稿にはお気に入り、コメントできない
+	_, err = posts.Like(ctx, &model.PostLikeUser{PostID: created.ID + 1000, UserID: two})
+	assert.Equal(t, KindNotFound, KindOf(err))
+	comment := model.Comment{PostID: created.ID + 1000, CreateUserID: two, CommentContent: testCommentContent}
+	_, err = posts.CreateComment(ctx, &comment)
+	assert.Equal(t, KindNotFound, KindOf(err))
+
+	// タグを削除すると投稿への紐付けも削除する
+	assert.Equal(t, nil, tags.DeleteByID(ctx, tag.ID))
+	issues, err := posts.CheckIntegrity(ctx, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(issues))
+
+	// 完全削除した投稿の紐付けは残らない
+	comment = model.Comment{PostID: created.ID, CreateUserID: two, CommentContent: testCommentContent}
+	_, err = posts.CreateComment(ctx, &comment)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, nil, posts.DeleteByID(ctx, created.ID))
+	assert.Equal(t, nil, posts.Purge(ctx, created.ID))
+	issues, err = posts.CheckIntegrity(ctx, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 0, len(issues))
+}
+
+func testConformanceIntegrityOrphans(t *testing.T, posts repository.PostRepository, tags repository.TagRepository) {
+	tag := createConformanceTag(t, tags, "tag", ValidTagStatus)
+	created := createConformancePost(t, posts, one, tag.ID)
+	inserter, ok := posts.(uncheckedInserter)
+	if !ok {
+		t.Fatalf("%T cannot insert relations without checking references", posts)
+	}
+	err := inserter.InsertUnchecked(
+		&model.PostTag{PostID: orphanPostID, TagID: tag.ID},
+		&model.PostTag{PostID: created.ID, TagID: orphanPostID},
+		// 投稿とタグの両方が存在しない紐付けは、postsの件数としてのみ数える
+		&model.PostTag{PostID: orphanPostID + 1, TagID: orphanPostID},
+		&model.PostLikeUser{PostID: orphanPostID, UserID: one},
+		&model.Comment{CommentID: orphanPostID, PostID: orphanPostID, CreateUserID: one, CommentContent: testCommentContent},
+		&model.PostRevision{ID: orphanPostID, PostID: orphanPostID, Revision: one},
+		// 参照先のない更新履歴のタグと、存在しない更新履歴のタグ
+		&model.PostRevisionTag{RevisionID: orphanPostID, TagID: tag.ID},
+		&model.PostRevisionTag{RevisionID: orphanPostID + 1, TagID: tag.ID},
+	)
+	assert.Equal(t, nil, err)
+
+	testCheckIntegrity(t, posts, map[string]int64{
+		"post_tags/posts":                   2,
+		"post_tags/tags":                    1,
+		"post_like_users/posts":             1,
+		"comments/posts":                    1,
+		"post_revisions/posts":              1,
+		"post_revision_tags/post_revisions": 2,
+	})
+
+	// 参照先のある紐付けは残る
+	joinPost, err := posts.GetJoinPostByID(context.Background(), created.ID, 0)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(joinPost.PostTags))
+}
+
+// createConformancePost 公開中の投稿を1件作成する
+func createConformancePost(t *testing.T, posts repository.PostRepository, userID uint32, tagIDs ...uint32) model.Post {
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = userID
+	var postTags []model.PostTag
+	for _, tagID := range tagIDs {
+		postTags = append(postTags, model.PostTag{TagID: tagID})
+	}
+	if _, err := posts.Create(context.Background(), &model.JoinPost{Post: &post, PostTags: postTags}); err != nil {
+		t.Fatal(err)
+	}
+	return post
+}
+
+// createConformanceTag タグを1件作成する
+func createConformanceTag(t *testing.T, tags repository.TagRepository, tagName string, status uint32) model.Tag {
+	tag := &model.Tag{TagName: tagName, CreateUserID: testUserID, Status: status}
+	if _, err := tags.Create(context.Background(), tag); err != nil {
+		t.Fatal(err)
+	}
+	return *tag
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/yzmw1213/PostService/db"
 	"github.com/yzmw1213/PostService/domain/model"
 	"github.com/yzmw1213/PostService/usecase/repository"
@@ -26,34 +25,36 @@ type TagInteractor struct{}
 var _ repository.TagRepository = (*TagInteractor)(nil)
 
 // Create タグ1件を作成
-func (i *TagInteractor) Create(postData *model.Tag) (*model.Tag, error) {
-	validate = validator.New()
-	DB := db.GetDB()
+func (i *TagInteractor) Create(ctx context.Context, postData *model.Tag) (*model.Tag, error) {
+	DB := db.Conn(ctx)
 
 	// Tag構造体のバリデーション
-	if err := validate.Struct(postData); err != nil {
+	if err := model.Validate(postData); err != nil {
 		return postData, err
 	}
 	if err := DB.Create(postData).Error; err != nil {
 		return postData, err
 	}
 
-	return postData, err
+	return postData, nil
 }
 
-// DeleteByID 指定したIDのタグ1件を削除
-func (i *TagInteractor) DeleteByID(id uint32) error {
-	DB := db.GetDB()
-	if err := DB.Where("id = ? ", id).Delete(&tag).Error; err != nil {
-		return err
-	}
-	return nil
+// DeleteByID 指定したIDのタグ1件を、投稿への紐付けと共に削除
+// 更新履歴のタグは過去の状態として残す
+func (i *TagInteractor) DeleteByID(ctx context.Context, id uint32) error {
+	return db.Transaction(ctx, func(ctx context.Context) error {
+		tx := db.Conn(ctx)
+		if err := tx.Where("tag_id = ?", id).Delete(&model.PostTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? ", id).Delete(&model.Tag{}).Error
+	})
 }
 
 // List タグを全件取得
-func (i *TagInteractor) List() ([]model.Tag, error) {
+func (i *TagInteractor) List(ctx context.Context) ([]model.Tag, error) {
 	var tagList []model.Tag
-	rows, err := listAllTag(context.Background())
+	rows, err := listAllTag(ctx)
 	if err != nil {
 		fmt.Println("Error happened")
 		return []model.Tag{}, err
@@ -66,8 +67,8 @@ func (i *TagInteractor) List() ([]model.Tag, error) {
 }
 
 // ListValidTag 有効タグを全件取得する
-func (i *TagInteractor) ListValidTag() ([]model.Tag, error) {
-	DB := db.GetDB()
+func (i *TagInteractor) ListValidTag(ctx context.Context) ([]model.Tag, error) {
+	DB := db.Conn(ctx)
 	var tags []model.Tag
 
 	err := DB.Order("created_at desc").Where("status = ?", ValidTagStatus).Select("tags.id, tags.tag_name, tags.status").Find(&tags).Error
@@ -80,10 +81,11 @@ func (i *TagInteractor) ListValidTag() ([]model.Tag, error) {
 
 // listAllTag タグ全件取得
 func listAllTag(ctx context.Context) ([]model.Tag, error) {
-	DB := db.GetDB()
+	DB := db.Conn(ctx)
 
-	_ = DB.Order("created_at desc").Find(&tags)
-	if err != nil {
+	var tags []model.Tag
+
+	if err := DB.Order("created_at desc").Find(&tags).Error; err != nil {
 		log.Println("Error occured")
 		return nil, err
 	}
@@ -91,15 +93,14 @@ func listAllTag(ctx context.Context) ([]model.Tag, error) {
 }
 
 // Update タグを更新する
-func (i *TagInteractor) Update(postData *model.Tag) (*model.Tag, error) {
-	DB := db.GetDB()
-	validate = validator.New()
-
+func (i *TagInteractor) Update(ctx context.Context, postData *model.Tag) (*model.Tag, error) {
+	DB := db.Conn(ctx)
 	// Tag構造体のバリデーション
-	if err := validate.Struct(postData); err != nil {
+	if err := model.Validate(postData); err != nil {
 		return postData, err
 	}
-	if err := DB.Model(&tag).Update(&postData).Error; err != nil {
+	// 指定したIDのタグのみ、値が設定された項目を更新する。作成ユーザーは変更しない
+	if err := DB.Model(postData).Omit("create_user_id").Updates(postData).Error; err != nil {
 		return postData, err
 	}
 
@@ -107,13 +108,13 @@ func (i *TagInteractor) Update(postData *model.Tag) (*model.Tag, error) {
 }
 
 // GetTagByTagName TagNameを元にタグを1件取得する
-func (i *TagInteractor) GetTagByTagName(tagName string) (model.Tag, error) {
+func (i *TagInteractor) GetTagByTagName(ctx context.Context, tagName string) (model.Tag, error) {
 	var tag model.Tag
 
-	DB := db.GetDB()
+	DB := db.Conn(ctx)
 	row := DB.Where("tag_name = ?", tagName).First(&tag)
 	if err := row.Error; err != nil {
-		return tag, err
+		return tag, wrapNotFound(err, ResourceTag, 0)
 	}
 	DB.Table(db.TagTableName).Scan(row)
 
@@ -121,13 +122,13 @@ func (i *TagInteractor) GetTagByTagName(tagName string) (model.Tag, error) {
 }
 
 // GetTagByTagID TagIDを元にタグを1件取得する
-func (i *TagInteractor) GetTagByTagID(tagID uint32) (model.Tag, error) {
+func (i *TagInteractor) GetTagByTagID(ctx context.Context, tagID uint32) (model.Tag, error) {
 	var tag model.Tag
 
-	DB := db.GetDB()
+	DB := db.Conn(ctx)
 	row := DB.Where("id = ?", tagID).First(&tag)
 	if err := row.Error; err != nil {
-		return tag, err
+		return tag, wrapNotFound(err, ResourceTag, tagID)
 	}
 	DB.Table(db.TagTableName).Scan(row)
 
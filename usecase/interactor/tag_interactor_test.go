@@ -1,6 +1,7 @@
 package interactor
 
 import (
+	"context"
 	"log"
 	"testing"
 
@@ -24,7 +25,7 @@ var DemoInvalidTag = model.Tag{
 func TestCreateTag(t *testing.T) {
 	var i TagInteractor
 	tag := &DemoValidTag
-	createdTag, err := i.Create(tag)
+	createdTag, err := i.Create(context.Background(), tag)
 
 	assert.Equal(t, nil, err)
 	assert.Equal(t, tag.CreateUserID, createdTag.CreateUserID)
@@ -36,7 +37,7 @@ func TestCreateTag(t *testing.T) {
 func TestCreateInvalidTag(t *testing.T) {
 	var i TagInteractor
 	tag := &DemoInvalidTag
-	createdTag, err := i.Create(tag)
+	createdTag, err := i.Create(context.Background(), tag)
 
 	assert.Equal(t, nil, err)
 	assert.Equal(t, tag.CreateUserID, createdTag.CreateUserID)
@@ -48,7 +49,7 @@ func TestCreateInvalidTag(t *testing.T) {
 func TestSearchValidTag(t *testing.T) {
 	var i TagInteractor
 
-	searchdTags, err := i.ListValidTag()
+	searchdTags, err := i.ListValidTag(context.Background())
 
 	assert.Equal(t, nil, err)
 
@@ -66,14 +67,14 @@ func TestDeleteTag(t *testing.T) {
 		CreateUserID: testUserID,
 		Status:       1,
 	}
-	createdTag, err := i.Create(tag)
+	createdTag, err := i.Create(context.Background(), tag)
 	assert.Equal(t, nil, err)
 	log.Printf("created tag id: %v\n", createdTag.ID)
 
-	err = i.DeleteByID(createdTag.ID)
+	err = i.DeleteByID(context.Background(), createdTag.ID)
 	assert.Equal(t, nil, err)
 
-	searchTag, err := i.GetTagByTagName(tagName)
+	searchTag, err := i.GetTagByTagName(context.Background(), tagName)
 	assert.NotEqual(t, nil, err)
 	assert.Equal(t, zero, searchTag.ID)
 	assert.Equal(t, "", searchTag.TagName)
@@ -88,17 +89,17 @@ func TestUpdateTagStatus(t *testing.T) {
 		CreateUserID: testUserID,
 		Status:       InValidTagStatus,
 	}
-	createdTag, err := i.Create(tag)
+	createdTag, err := i.Create(context.Background(), tag)
 	assert.Equal(t, nil, err)
 
 	log.Println(createdTag.Status)
 	inputTag := createdTag
 	inputTag.Status = ValidTagStatus
 
-	_, err = i.Update(inputTag)
+	_, err = i.Update(context.Background(), inputTag)
 
 	assert.Equal(t, nil, err)
-	searchTag, err := i.GetTagByTagName(tagName)
+	searchTag, err := i.GetTagByTagName(context.Background(), tagName)
 	assert.Equal(t, nil, err)
 	assert.Equal(t, tagName, searchTag.TagName)
 	assert.Equal(t, ValidTagStatus, searchTag.Status)
@@ -107,7 +108,7 @@ func TestUpdateTagStatus(t *testing.T) {
 // TestListTag タグ全件取得
 func TestListTag(t *testing.T) {
 	var i TagInteractor
-	tags, err = i.List()
+	tags, err = i.List(context.Background())
 	log.Println(len(tags))
 	assert.Equal(t, nil, err)
 }
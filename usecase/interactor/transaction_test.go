@@ -0,0 +1,82 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/yzmw1213/PostService/domain/model"
+)
+
+// TestConcurrentCreateAndUpdate 並行した作成、更新がそれぞれのトランザクションで完結する事をテスト
+// go test -race で実行し、トランザクションの共有によるデータ競合がない事を確認する
+func TestConcurrentCreateAndUpdate(t *testing.T) {
+	var i PostInteractor
+	const workers = 8
+	ctx := context.Background()
+
+	post := makePost(testTitle, testContent)
+	post.CreateUserID = user1
+	joinPost := makeJoinPost(post, DemoUser, nil, nil, nil)
+	target, err := i.Create(ctx, &joinPost)
+	assert.Equal(t, nil, err)
+	targetID := target.Post.ID
+
+	var wg sync.WaitGroup
+	createdIDs := make(chan uint32, workers)
+	errs := make(chan error, workers*2)
+	for n := 0; n < workers; n++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			post := makePost(testTitle, testContent)
+			post.CreateUserID = user2
+			joinPost := makeJoinPost(post, DemoUser, makePostTags(), nil, nil)
+			created, err := i.Create(ctx, &joinPost)
+			if err != nil {
+				errs <- err
+				return
+			}
+			createdIDs <- created.Post.ID
+		}()
+		go func(tagID uint32) {
+			defer wg.Done()
+			// 他の更新と競合した場合は、読み込み直して更新する
+			for {
+				current, err := i.GetByID(ctx, targetID)
+				if err != nil {
+					errs <- err
+					return
+				}
+				post := model.Post{ID: targetID, Title: testTitle, Content: testContent, CreateUserID: user1, UpdateUserID: user1, Version: current.Version}
+				joinPost := makeJoinPost(post, DemoUser, []model.PostTag{{TagID: tagID}}, nil, nil)
+				_, err = i.Update(ctx, &joinPost)
+				if errors.Is(err, ErrVersionConflict) {
+					continue
+				}
+				if err != nil {
+					errs <- err
+				}
+				return
+			}
+		}(uint32(n + 1))
+	}
+	wg.Wait()
+	close(createdIDs)
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	// 作成した投稿には、それぞれのトランザクションで登録したタグのみが紐付く
+	for id := range createdIDs {
+		assert.Equal(t, 3, countPostTagByID(id))
+	}
+	// 更新はそれぞれ独立して反映され、最後の更新のタグのみが残る
+	assert.Equal(t, 1, countPostTagByID(targetID))
+	revisions, err := i.ListRevisions(ctx, targetID)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, workers+1, len(revisions))
+}
@@ -0,0 +1,42 @@
+package interactor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	// ErrVersionConflict 更新対象が他の更新により変更されていた時のエラー
+	ErrVersionConflict = errors.New("version conflict")
+	// ErrVersionRequired 更新時に版数が指定されていない時のエラー
+	ErrVersionRequired = errors.New("version is required")
+)
+
+// incrementVersion 版数が expected と一致する場合のみ版数を1つ進め、進めた後の版数を返す
+// resourceはエラーで返す対象のリソース種別
+// expected が0の場合は、確認せずに上書きしないよう ErrVersionRequired を返す
+// トランザクション内で呼ぶことで、コミットまで対象行への他の更新を待たせる
+func incrementVersion(tx *gorm.DB, value interface{}, resource string, key string, id uint32, expected uint32) (uint32, error) {
+	var versions []uint32
+	where := fmt.Sprintf("%s = ?", key)
+
+	if expected == 0 {
+		return 0, ErrVersionRequired
+	}
+	result := tx.Model(value).Where(where, id).Where("version = ?", expected).UpdateColumn("version", gorm.Expr("version + 1"))
+	if err := result.Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Model(value).Where(where, id).Pluck("version", &versions).Error; err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, NewNotFoundError(resource, id)
+	}
+	if result.RowsAffected == 0 {
+		return 0, NewVersionConflictError(resource, id)
+	}
+	return versions[0], nil
+}
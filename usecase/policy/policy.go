@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+)
+
+// IsModerator 全ての投稿、コメントを非表示にできる権限か判定する
+func IsModerator(actor model.User) bool {
+	switch actor.Authority {
+	case model.AuthorityModerator, model.AuthorityOperator, model.AuthorityAdmin:
+		return true
+	}
+	return false
+}
+
+// IsAdmin 管理者か判定する
+func IsAdmin(actor model.User) bool {
+	return actor.Authority == model.AuthorityAdmin
+}
+
+// CanEditPost 投稿を編集、削除、復元できるか判定する。投稿者本人のみ操作できる
+func CanEditPost(actor model.User, post model.Post) error {
+	if actor.ID != 0 && actor.ID == post.CreateUserID {
+		return nil
+	}
+	return interactor.NewPermissionDeniedError(interactor.ResourcePost, post.ID)
+}
+
+// CanHidePost 投稿を非表示にできるか判定する。投稿者本人とモデレーターが操作できる
+func CanHidePost(actor model.User, post model.Post) error {
+	if IsModerator(actor) {
+		return nil
+	}
+	return CanEditPost(actor, post)
+}
+
+// CanUnhidePost 非表示の投稿を status に変更できるか判定する。モデレーターのみ操作できる
+// ステータスを変更しない場合、非表示以外の投稿の場合は判定しない
+func CanUnhidePost(actor model.User, post model.Post, status uint32) error {
+	if post.Status != interactor.HiddenPostStatus || status == 0 || status == interactor.HiddenPostStatus {
+		return nil
+	}
+	if IsModerator(actor) {
+		return nil
+	}
+	return interactor.NewPermissionDeniedError(interactor.ResourcePost, post.ID)
+}
+
+// CanRevertPost 投稿を過去の版に差し戻せるか判定する。荒らしへの対応のため、投稿者本人とモデレーターが操作できる
+func CanRevertPost(actor model.User, post model.Post) error {
+	if IsModerator(actor) {
+		return nil
+	}
+	return CanEditPost(actor, post)
+}
+
+// CanViewPostHistory 非公開、ゴミ箱の投稿の更新履歴を参照できるか判定する。投稿者本人とモデレーターが参照できる
+func CanViewPostHistory(actor model.User, post model.Post) error {
+	if IsModerator(actor) {
+		return nil
+	}
+	return CanEditPost(actor, post)
+}
+
+// CanEditComment コメントを編集できるか判定する。投稿者本人のみ操作できる
+func CanEditComment(actor model.User, comment model.Comment) error {
+	if actor.ID != 0 && actor.ID == comment.CreateUserID {
+		return nil
+	}
+	return interactor.NewPermissionDeniedError(interactor.ResourceComment, comment.CommentID)
+}
+
+// CanDeleteComment コメントを削除(非表示に)できるか判定する。投稿者本人とモデレーターが操作できる
+func CanDeleteComment(actor model.User, comment model.Comment) error {
+	if IsModerator(actor) {
+		return nil
+	}
+	return CanEditComment(actor, comment)
+}
+
+// CanManageTag タグを作成、更新、削除できるか判定する。管理者のみ操作できる
+// 作成時はタグIDが決まっていないため、tagIDに0を指定する
+func CanManageTag(actor model.User, tagID uint32) error {
+	if IsAdmin(actor) {
+		return nil
+	}
+	return interactor.NewPermissionDeniedError(interactor.ResourceTag, tagID)
+}
+
+// CanDeleteUserContents 退会したユーザーの投稿、コメントを削除できるか判定する。管理者のみ操作できる
+func CanDeleteUserContents(actor model.User) error {
+	if IsAdmin(actor) {
+		return nil
+	}
+	return interactor.NewPermissionDeniedError(interactor.ResourcePost, 0)
+}
+
+// CanCheckIntegrity 紐付け情報の整合性を検査、修復できるか判定する。管理者のみ操作できる
+func CanCheckIntegrity(actor model.User) error {
+	if IsAdmin(actor) {
+		return nil
+	}
+	return interactor.NewPermissionDeniedError(interactor.ResourcePost, 0)
+}
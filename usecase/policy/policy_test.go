@@ -0,0 +1,103 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/interactor"
+)
+
+const (
+	// ownerID 投稿、コメントの投稿者
+	ownerID uint32 = 1
+)
+
+// TestPolicyMatrix 操作するユーザーの権限と、操作ごとに許可されるか判定する
+func TestPolicyMatrix(t *testing.T) {
+	post := model.Post{ID: 10, CreateUserID: ownerID}
+	hidden := model.Post{ID: 11, CreateUserID: ownerID, Status: interactor.HiddenPostStatus}
+	comment := model.Comment{CommentID: 20, PostID: post.ID, CreateUserID: ownerID}
+
+	actors := map[string]model.User{
+		"anonymous": {},
+		"owner":     {ID: ownerID, Authority: model.AuthorityUser},
+		"other":     {ID: 2, Authority: model.AuthorityUser},
+		"moderator": {ID: 3, Authority: model.AuthorityModerator},
+		"operator":  {ID: 4, Authority: model.AuthorityOperator},
+		"admin":     {ID: 5, Authority: model.AuthorityAdmin},
+	}
+	actions := map[string]func(actor model.User) error{
+		"EditPost":           func(actor model.User) error { return CanEditPost(actor, post) },
+		"HidePost":           func(actor model.User) error { return CanHidePost(actor, post) },
+		"UnhidePost":         func(actor model.User) error { return CanUnhidePost(actor, hidden, interactor.PublishedPostStatus) },
+		"RevertPost":         func(actor model.User) error { return CanRevertPost(actor, post) },
+		"ViewPostHistory":    func(actor model.User) error { return CanViewPostHistory(actor, post) },
+		"EditComment":        func(actor model.User) error { return CanEditComment(actor, comment) },
+		"DeleteComment":      func(actor model.User) error { return CanDeleteComment(actor, comment) },
+		"ManageTag":          func(actor model.User) error { return CanManageTag(actor, 30) },
+		"DeleteUserContents": func(actor model.User) error { return CanDeleteUserContents(actor) },
+		"CheckIntegrity":     func(actor model.User) error { return CanCheckIntegrity(actor) },
+	}
+
+	tests := []struct {
+		action  string
+		allowed []string
+	}{
+		{"EditPost", []string{"owner"}},
+		{"HidePost", []string{"owner", "moderator", "operator", "admin"}},
+		{"UnhidePost", []string{"moderator", "operator", "admin"}},
+		{"RevertPost", []string{"owner", "moderator", "operator", "admin"}},
+		{"ViewPostHistory", []string{"owner", "moderator", "operator", "admin"}},
+		{"EditComment", []string{"owner"}},
+		{"DeleteComment", []string{"owner", "moderator", "operator", "admin"}},
+		{"ManageTag", []string{"admin"}},
+		{"DeleteUserContents", []string{"admin"}},
+		{"CheckIntegrity", []string{"admin"}},
+	}
+	for _, tt := range tests {
+		allowed := map[string]bool{}
+		for _, name := range tt.allowed {
+			allowed[name] = true
+		}
+		for name, actor := range actors {
+			t.Run(tt.action+"/"+name, func(t *testing.T) {
+				err := actions[tt.action](actor)
+				if allowed[name] {
+					if err != nil {
+						t.Errorf("%s by %s: got %v, want allowed", tt.action, name, err)
+					}
+					return
+				}
+				if interactor.KindOf(err) != interactor.KindPermissionDenied {
+					t.Errorf("%s by %s: got %v, want permission denied", tt.action, name, err)
+				}
+			})
+		}
+	}
+}
+
+// TestPermissionDeniedResource 権限がない場合のエラーが対象のリソースを持つ事をテスト
+func TestPermissionDeniedResource(t *testing.T) {
+	other := model.User{ID: 2}
+	tests := []struct {
+		name     string
+		err      error
+		resource string
+		id       uint32
+	}{
+		{"post", CanEditPost(other, model.Post{ID: 10, CreateUserID: ownerID}), interactor.ResourcePost, 10},
+		{"comment", CanEditComment(other, model.Comment{CommentID: 20, CreateUserID: ownerID}), interactor.ResourceComment, 20},
+		{"tag", CanManageTag(other, 30), interactor.ResourceTag, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domainErr, ok := tt.err.(*interactor.Error)
+			if !ok {
+				t.Fatalf("got %T, want *interactor.Error", tt.err)
+			}
+			if domainErr.Resource != tt.resource || domainErr.ID != tt.id {
+				t.Errorf("got %s/%d, want %s/%d", domainErr.Resource, domainErr.ID, tt.resource, tt.id)
+			}
+		})
+	}
+}
@@ -1,30 +1,69 @@
 package repository
 
-import "github.com/yzmw1213/PostService/domain/model"
+import (
+	"context"
+	"time"
+
+	"github.com/yzmw1213/PostService/domain/model"
+)
 
 // PostRepository 投稿サービスの抽象定義
 type PostRepository interface {
-	Create(*model.JoinPost) (*model.JoinPost, error)
-	GetByID(id uint32) (model.Post, error)
-	GetJoinPostByID(id uint32) (model.JoinPost, error)
-	DeleteByID(id uint32) error
-	List(condition string, userID uint32) ([]model.JoinPost, error)
-	Update(*model.JoinPost) (*model.JoinPost, error)
-	DeletePostsByUserID(userID uint32) error
-	Like(*model.PostLikeUser) (*model.PostLikeUser, error)
-	NotLike(*model.PostLikeUser) (*model.PostLikeUser, error)
-	CreateComment(*model.Comment) (*model.Comment, error)
-	UpdateComment(*model.Comment) (*model.Comment, error)
-	DeleteComment(id uint32) error
-	DeleteCommentsByUserID(userID uint32) error
+	Create(context.Context, *model.JoinPost) (*model.JoinPost, error)
+	GetByID(ctx context.Context, id uint32) (model.Post, error)
+	GetJoinPostByID(ctx context.Context, id uint32, viewerID uint32) (model.JoinPost, error)
+	GetTrashedByID(ctx context.Context, id uint32) (model.Post, error)
+	DeleteByID(ctx context.Context, id uint32) error
+	Restore(ctx context.Context, id uint32) error
+	ListTrashed(ctx context.Context, userID uint32, page model.Pagination) ([]model.JoinPost, string, error)
+	Purge(ctx context.Context, id uint32) error
+	PurgeTrashed(ctx context.Context, before time.Time) (int64, error)
+	List(ctx context.Context, condition model.PostListCondition, page model.Pagination) ([]model.JoinPost, string, error)
+	Search(ctx context.Context, query model.PostSearchQuery, page model.Pagination) ([]model.JoinPost, string, error)
+	Update(context.Context, *model.JoinPost) (*model.JoinPost, error)
+	ListRevisions(ctx context.Context, postID uint32) ([]model.JoinPostRevision, error)
+	GetRevision(ctx context.Context, postID uint32, revision uint32) (model.JoinPostRevision, model.PostRevisionDiff, error)
+	RevertToRevision(ctx context.Context, postID uint32, revision uint32, version uint32, userID uint32) (*model.JoinPost, error)
+	DeletePostsByUserID(ctx context.Context, userID uint32) error
+	PublishScheduledPosts(ctx context.Context, now time.Time) (int64, error)
+	Like(context.Context, *model.PostLikeUser) (*model.PostLikeUser, error)
+	NotLike(context.Context, *model.PostLikeUser) (*model.PostLikeUser, error)
+	GetCommentByID(ctx context.Context, id uint32) (model.Comment, error)
+	CreateComment(context.Context, *model.Comment) (*model.Comment, error)
+	UpdateComment(context.Context, *model.Comment) (*model.Comment, error)
+	DeleteComment(ctx context.Context, id uint32) error
+	DeleteCommentsByUserID(ctx context.Context, userID uint32) error
+	CheckIntegrity(ctx context.Context, repair bool) ([]model.IntegrityIssue, error)
 }
 
 // TagRepository タグサービスの抽象定義
 type TagRepository interface {
-	Create(*model.Tag) (*model.Tag, error)
-	DeleteByID(uint32) error
-	GetTagByTagName(string) (model.Tag, error)
-	ListValidTag() ([]model.Tag, error)
-	List() ([]model.Tag, error)
-	Update(*model.Tag) (*model.Tag, error)
+	Create(context.Context, *model.Tag) (*model.Tag, error)
+	DeleteByID(context.Context, uint32) error
+	GetTagByTagName(context.Context, string) (model.Tag, error)
+	GetTagByTagID(context.Context, uint32) (model.Tag, error)
+	ListValidTag(context.Context) ([]model.Tag, error)
+	List(context.Context) ([]model.Tag, error)
+	Update(context.Context, *model.Tag) (*model.Tag, error)
+}
+
+// SearchIndex 投稿の全文検索インデックスの抽象定義
+type SearchIndex interface {
+	Index(ctx context.Context, post model.Post, tagIDs []uint32) error
+	Remove(ctx context.Context, postID uint32) error
+	Search(ctx context.Context, query model.PostSearchQuery, limit int) ([]model.SearchHit, error)
+}
+
+// UserDirectory ユーザー情報の参照先の抽象定義
+type UserDirectory interface {
+	// LookupUsers 指定したIDのユーザー情報を返す。存在しないユーザーは結果に含めない
+	LookupUsers(ctx context.Context, ids []uint32) (map[uint32]model.User, error)
+}
+
+// ImageStorage 投稿画像の保存先の抽象定義
+type ImageStorage interface {
+	// Upload base64形式の画像を保存し、保存先のキーを返す
+	Upload(ctx context.Context, imageBase64 string) (string, error)
+	// URL 保存先のキーから画像のURLを返す
+	URL(key string) string
 }
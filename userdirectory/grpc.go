@@ -0,0 +1,162 @@
+package userdirectory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/grpc/userservice"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// DefaultTTL ユーザー情報をキャッシュする期間のデフォルト値
+const DefaultTTL = time.Minute
+
+// GRPCDirectory UserServiceからユーザー情報を取得するUserDirectory
+// 取得したユーザー情報はTTLの間キャッシュし、UserServiceへの接続は全てのリクエストで共有する
+// ListUserのuser_idsに対応していないUserServiceは全件を返すため、その場合は返された全てのユーザーをキャッシュする
+type GRPCDirectory struct {
+	conn   *grpc.ClientConn
+	client userservice.UserServiceClient
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	users map[uint32]cachedUser
+}
+
+// cachedUser キャッシュしたユーザー情報
+// UserServiceに存在しなかったIDもfound=falseとしてキャッシュし、問い合わせを繰り返さない
+type cachedUser struct {
+	user      model.User
+	found     bool
+	expiresAt time.Time
+}
+
+var _ repository.UserDirectory = (*GRPCDirectory)(nil)
+
+// Dial UserServiceに接続し、GRPCDirectoryを生成する
+// 接続は最初のリクエスト時に確立されるため、UserServiceが停止していてもエラーにしない
+func Dial(target string, ttl time.Duration) (*GRPCDirectory, error) {
+	conn, err := grpc.Dial(target, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	directory := NewGRPCDirectory(userservice.NewUserServiceClient(conn), ttl)
+	directory.conn = conn
+	return directory, nil
+}
+
+// NewGRPCDirectory UserServiceのクライアントを元にGRPCDirectoryを生成する
+func NewGRPCDirectory(client userservice.UserServiceClient, ttl time.Duration) *GRPCDirectory {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &GRPCDirectory{
+		client: client,
+		ttl:    ttl,
+		now:    time.Now,
+		users:  map[uint32]cachedUser{},
+	}
+}
+
+// Close UserServiceとの接続を閉じる
+func (d *GRPCDirectory) Close() error {
+	if d.conn == nil {
+		return nil
+	}
+	return d.conn.Close()
+}
+
+// Ping UserServiceに接続できるか検査する
+// UserServiceがヘルスチェックを実装していない場合も、応答があれば接続できたものとする
+func (d *GRPCDirectory) Ping(ctx context.Context) error {
+	if d.conn == nil {
+		return nil
+	}
+	res, err := healthpb.NewHealthClient(d.conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("user service is %v", res.GetStatus())
+	}
+	return nil
+}
+
+// LookupUsers 指定したIDのユーザー情報を返す
+// キャッシュにないIDのみUserServiceに問い合わせる
+// UserServiceに接続できない場合は、期限切れのキャッシュと共にエラーを返す
+func (d *GRPCDirectory) LookupUsers(ctx context.Context, ids []uint32) (map[uint32]model.User, error) {
+	users := map[uint32]model.User{}
+	seen := map[uint32]bool{}
+	var missing []uint32
+
+	now := d.now()
+	d.mu.Lock()
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		cached, ok := d.users[id]
+		if ok && now.Before(cached.expiresAt) {
+			if cached.found {
+				users[id] = cached.user
+			}
+			continue
+		}
+		missing = append(missing, id)
+	}
+	d.mu.Unlock()
+
+	if len(missing) == 0 {
+		return users, nil
+	}
+
+	res, err := d.client.ListUser(ctx, &userservice.ListUserRequest{UserIds: missing})
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		log.Printf("failed to lookup users from UserService: %v\n", err)
+		for _, id := range missing {
+			if cached, ok := d.users[id]; ok && cached.found {
+				users[id] = cached.user
+			}
+		}
+		return users, err
+	}
+
+	expiresAt := d.now().Add(d.ttl)
+	for _, profile := range res.GetProfile() {
+		d.users[profile.GetUserId()] = cachedUser{
+			user: model.User{
+				ID:        profile.GetUserId(),
+				UserName:  profile.GetUserName(),
+				Authority: profile.GetAuthority(),
+			},
+			found:     true,
+			expiresAt: expiresAt,
+		}
+	}
+	for _, id := range missing {
+		cached, ok := d.users[id]
+		if !ok || !cached.found {
+			d.users[id] = cachedUser{found: false, expiresAt: expiresAt}
+			continue
+		}
+		users[id] = cached.user
+	}
+	return users, nil
+}
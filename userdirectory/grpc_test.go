@@ -0,0 +1,184 @@
+package userdirectory
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/grpc/userservice"
+)
+
+// fakeUserServiceClient 登録済みのユーザーを返すUserServiceのクライアント
+type fakeUserServiceClient struct {
+	profiles map[uint32]*userservice.UserProfile
+	requests [][]uint32
+	err      error
+	// ignoreUserIDs user_idsに対応していないUserServiceとして、常に全件を返す
+	ignoreUserIDs bool
+}
+
+func (c *fakeUserServiceClient) ListUser(ctx context.Context, in *userservice.ListUserRequest, opts ...grpc.CallOption) (*userservice.ListUserResponse, error) {
+	c.requests = append(c.requests, in.GetUserIds())
+	if c.err != nil {
+		return nil, c.err
+	}
+	res := &userservice.ListUserResponse{}
+	if c.ignoreUserIDs {
+		for _, profile := range c.profiles {
+			res.Profile = append(res.Profile, profile)
+		}
+		return res, nil
+	}
+	for _, id := range in.GetUserIds() {
+		if profile, ok := c.profiles[id]; ok {
+			res.Profile = append(res.Profile, profile)
+		}
+	}
+	return res, nil
+}
+
+func newFakeClient() *fakeUserServiceClient {
+	return &fakeUserServiceClient{
+		profiles: map[uint32]*userservice.UserProfile{
+			1: {UserId: 1, UserName: "testuser1", Authority: 1},
+			2: {UserId: 2, UserName: "testuser2"},
+		},
+	}
+}
+
+// TestLookupUsersCache キャッシュにないIDのみ問い合わせる事をテスト
+func TestLookupUsersCache(t *testing.T) {
+	client := newFakeClient()
+	d := NewGRPCDirectory(client, time.Minute)
+
+	users, err := d.LookupUsers(context.Background(), []uint32{1, 1, 3})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, model.User{ID: 1, UserName: "testuser1", Authority: 1}, users[1])
+	_, ok := users[3]
+	assert.Equal(t, false, ok)
+	assert.Equal(t, [][]uint32{{1, 3}}, client.requests)
+
+	// 存在しなかったIDもキャッシュし、新しいIDのみ問い合わせる
+	users, err = d.LookupUsers(context.Background(), []uint32{1, 2, 3})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "testuser2", users[2].UserName)
+	assert.Equal(t, [][]uint32{{1, 3}, {2}}, client.requests)
+}
+
+// TestLookupUsersIgnoredUserIDs user_idsに対応していないUserServiceでも、指定したIDのユーザーのみ返す事をテスト
+func TestLookupUsersIgnoredUserIDs(t *testing.T) {
+	client := newFakeClient()
+	client.ignoreUserIDs = true
+	d := NewGRPCDirectory(client, time.Minute)
+
+	users, err := d.LookupUsers(context.Background(), []uint32{1})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(users))
+	assert.Equal(t, "testuser1", users[1].UserName)
+
+	// 返された他のユーザーもキャッシュし、問い合わせない
+	users, err = d.LookupUsers(context.Background(), []uint32{2})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "testuser2", users[2].UserName)
+	assert.Equal(t, 1, len(client.requests))
+}
+
+// TestLookupUsersExpired TTLを過ぎたユーザー情報を再取得する事をテスト
+func TestLookupUsersExpired(t *testing.T) {
+	client := newFakeClient()
+	d := NewGRPCDirectory(client, time.Minute)
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	d.LookupUsers(context.Background(), []uint32{1})
+	client.profiles[1].UserName = "renamed1"
+
+	now = now.Add(59 * time.Second)
+	users, _ := d.LookupUsers(context.Background(), []uint32{1})
+	assert.Equal(t, "testuser1", users[1].UserName)
+
+	now = now.Add(time.Second)
+	users, _ = d.LookupUsers(context.Background(), []uint32{1})
+	assert.Equal(t, "renamed1", users[1].UserName)
+	assert.Equal(t, 2, len(client.requests))
+}
+
+// TestLookupUsersUnavailable UserServiceの障害時に期限切れのキャッシュを返す事をテスト
+func TestLookupUsersUnavailable(t *testing.T) {
+	client := newFakeClient()
+	d := NewGRPCDirectory(client, time.Minute)
+	now := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	d.LookupUsers(context.Background(), []uint32{1})
+	now = now.Add(time.Hour)
+	client.err = errors.New("unavailable")
+
+	users, err := d.LookupUsers(context.Background(), []uint32{1, 2})
+	assert.Equal(t, client.err, err)
+	assert.Equal(t, "testuser1", users[1].UserName)
+	_, ok := users[2]
+	assert.Equal(t, false, ok)
+}
+
+// pingDirectory bufconnで起動したサーバーに接続したGRPCDirectoryを返す
+func pingDirectory(t *testing.T, register func(s *grpc.Server)) (*GRPCDirectory, *grpc.Server) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	register(s)
+	go s.Serve(lis)
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+		return lis.Dial()
+	}), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewGRPCDirectory(userservice.NewUserServiceClient(conn), time.Minute)
+	d.conn = conn
+	t.Cleanup(func() {
+		d.Close()
+		s.Stop()
+	})
+	return d, s
+}
+
+// TestPing UserServiceの応答の有無で接続できるか判定する事をテスト
+func TestPing(t *testing.T) {
+	// ヘルスチェックを実装していなくても、応答があれば接続できたものとする
+	d, s := pingDirectory(t, func(s *grpc.Server) {})
+	assert.Equal(t, nil, d.Ping(context.Background()))
+
+	s.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.NotEqual(t, nil, d.Ping(ctx))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	d, _ = pingDirectory(t, func(s *grpc.Server) { healthpb.RegisterHealthServer(s, healthServer) })
+	assert.NotEqual(t, nil, d.Ping(context.Background()))
+}
+
+// TestMemoryDirectory 登録したユーザーのみ返す事をテスト
+func TestMemoryDirectory(t *testing.T) {
+	d := NewMemoryDirectory(model.User{ID: 1, UserName: "testuser1"})
+	d.Put(model.User{ID: 2, UserName: "testuser2"})
+
+	users, err := d.LookupUsers(context.Background(), []uint32{1, 2, 3})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(users))
+
+	d.SetError(errors.New("unavailable"))
+	users, err = d.LookupUsers(context.Background(), []uint32{1})
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 0, len(users))
+}
@@ -0,0 +1,58 @@
+package userdirectory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yzmw1213/PostService/domain/model"
+	"github.com/yzmw1213/PostService/usecase/repository"
+)
+
+// MemoryDirectory メモリ上のユーザー情報を返すUserDirectory
+// UserServiceを起動せずにテストするために用いる
+type MemoryDirectory struct {
+	mu    sync.RWMutex
+	users map[uint32]model.User
+	err   error
+}
+
+var _ repository.UserDirectory = (*MemoryDirectory)(nil)
+
+// NewMemoryDirectory 指定したユーザーを登録したMemoryDirectoryを生成する
+func NewMemoryDirectory(users ...model.User) *MemoryDirectory {
+	d := &MemoryDirectory{users: map[uint32]model.User{}}
+	for _, user := range users {
+		d.users[user.ID] = user
+	}
+	return d
+}
+
+// Put ユーザーを登録する
+func (d *MemoryDirectory) Put(user model.User) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.users[user.ID] = user
+}
+
+// SetError LookupUsersが返すエラーを設定する。UserServiceの障害を再現するために用いる
+func (d *MemoryDirectory) SetError(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.err = err
+}
+
+// LookupUsers 指定したIDのうち、登録済みのユーザー情報を返す
+func (d *MemoryDirectory) LookupUsers(ctx context.Context, ids []uint32) (map[uint32]model.User, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	users := map[uint32]model.User{}
+	if d.err != nil {
+		return users, d.err
+	}
+	for _, id := range ids {
+		if user, ok := d.users[id]; ok {
+			users[id] = user
+		}
+	}
+	return users, nil
+}